@@ -14,16 +14,16 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
-	"os"
-	"sort"
-	"strings"
-	"sync"
+	"path/filepath"
+	"time"
 
-	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
-
-	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
 
 	"github.com/spf13/pflag"
 
@@ -54,11 +54,49 @@ type ImportOptions struct {
 	NoSort        bool
 	RetryCount    int
 	RetrySleepMs  int
+	// Timeout bounds the whole import, ServiceTimeout the listing of each service
+	Timeout        time.Duration
+	ServiceTimeout time.Duration
+	// ProviderVersion is a version constraint on the provider binary used
+	ProviderVersion string
+	// Stream imports and writes services one at a time to bound memory
+	Stream bool `json:"-"`
+	// Converter is flatmap or cty, see terraformer.Options
+	Converter string `json:",omitempty"`
+	// StateFormat is v3 or v4
+	StateFormat string `json:",omitempty"`
+	// Verify runs terraform plan on the output, see terraformer.Verify
+	Verify       bool   `json:",omitempty"`
+	VerifyBinary string `json:",omitempty"`
+	// VerifyStrict fails the import when verification finds diffs
+	VerifyStrict bool `json:",omitempty"`
+	// VerifyReport is the path of the verification.json report, none is written when empty
+	VerifyReport string `json:",omitempty"`
+	// Heal fixes the diffs found by verification, see terraformer.Heal
+	Heal              bool `json:",omitempty"`
+	HealMaxIterations int  `json:",omitempty"`
+	// HealRules is the rules file the fixes of Heal are merged into, none is written when empty
+	HealRules string `json:",omitempty"`
+	// Rules is the path of a rules file overriding the configuration of resource types
+	Rules string `json:",omitempty"`
+	// StripDefaults removes attributes equal to their provider default
+	StripDefaults bool `json:",omitempty"`
+	// DefaultTags lifts common tags to the provider, default_tags of aws and default_labels of google
+	DefaultTags bool `json:",omitempty"`
+	// Parameterize replaces account IDs, projects, regions and zones with variables and data sources
+	Parameterize bool `json:",omitempty"`
+	// DataSources looks up connection targets that aren't imported with data sources
+	DataSources bool `json:",omitempty"`
+	// Naming is a template naming resources, e.g. {type}_{tags.Name|name|id}
+	Naming string `json:",omitempty"`
 }
 
-const DefaultPathPattern = "{output}/{provider}/{service}/"
-const DefaultPathOutput = "generated"
-const DefaultState = "local"
+const DefaultPathPattern = terraformer.DefaultPathPattern
+const DefaultPathOutput = terraformer.DefaultPathOutput
+const DefaultState = terraformer.DefaultState
+
+// importContext is canceled on interrupt, see Execute
+var importContext = context.Background()
 
 func newImportCmd() *cobra.Command {
 	options := ImportOptions{}
@@ -86,295 +124,202 @@ func newImportCmd() *cobra.Command {
 }
 
 func Import(provider terraformutils.ProviderGenerator, options ImportOptions, args []string) error {
-
-	providerWrapper, options, err := initOptionsAndWrapper(provider, options, args)
-	if err != nil {
-		return err
-	}
-	defer providerWrapper.Kill()
-	providerMapping := terraformutils.NewProvidersMapping(provider)
-
-	err = initAllServicesResources(providerMapping, options, args, providerWrapper)
-	if err != nil {
-		return err
-	}
-
-	err = terraformutils.RefreshResourcesByProvider(providerMapping, providerWrapper)
-	if err != nil {
-		return err
-	}
-
-	providerMapping.ConvertTFStates(providerWrapper)
-	// change structs with additional data for each resource
-	providerMapping.CleanupProviders()
-
-	err = importFromPlan(providerMapping, options, args)
-
-	return err
-}
-
-func initOptionsAndWrapper(provider terraformutils.ProviderGenerator, options ImportOptions, args []string) (*providerwrapper.ProviderWrapper, ImportOptions, error) {
-	err := provider.Init(args)
-	if err != nil {
-		return nil, options, err
+	rules := terraformutils.Rules{}
+	if options.Rules != "" {
+		var err error
+		if rules, err = terraformutils.ReadRules(options.Rules); err != nil {
+			return err
+		}
 	}
-
-	if terraformerstring.ContainsString(options.Resources, "*") {
-		log.Println("Attempting an import of ALL resources in " + provider.GetName())
-		options.Resources = providerServices(provider)
+	if options.DataSources && !options.Connect {
+		return errors.New("--data-sources needs --connect")
 	}
-
-	// Deduplicate resources
-	seen := make(map[string]struct{})
-	var uniqueResources []string
-	for _, r := range options.Resources {
-		if _, ok := seen[r]; !ok {
-			seen[r] = struct{}{}
-			uniqueResources = append(uniqueResources, r)
+	if options.Stream {
+		if options.Plan {
+			return errors.New("--stream can't be used with plan")
 		}
-	}
-	options.Resources = uniqueResources
-
-	if len(options.Excludes) > 0 {
-		localSlice := []string{}
-		for _, r := range options.Resources {
-			remove := false
-			for _, e := range options.Excludes {
-				if r == e {
-					remove = true
-					log.Println("Excluding resource " + e)
-				}
-			}
-			if !remove {
-				localSlice = append(localSlice, r)
-			}
+		if options.Heal {
+			return errors.New("--heal can't be used with --stream")
 		}
-		options.Resources = localSlice
-	}
-
-	providerWrapper, err := providerwrapper.NewProviderWrapper(provider.GetName(), provider.GetConfig(), options.Verbose, map[string]int{"retryCount": options.RetryCount, "retrySleepMs": options.RetrySleepMs})
-	if err != nil {
-		return nil, options, err
-	}
-
-	return providerWrapper, options, nil
-}
-
-func initAllServicesResources(providersMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string, providerWrapper *providerwrapper.ProviderWrapper) error {
-	numOfResources := len(options.Resources)
-	var wg sync.WaitGroup
-	wg.Add(numOfResources)
-
-	var failedServices []string
-
-	for _, service := range options.Resources {
-		serviceProvider := providersMapping.AddServiceToProvider(service)
-		err := serviceProvider.Init(args)
-		if err != nil {
+		paths, err := writeStaged(options, func(libraryOptions terraformer.Options) error {
+			libraryOptions.Rules = rules
+			_, err := terraformer.Stream(importContext, provider, args, libraryOptions)
 			return err
-		}
-		err = initServiceResources(service, serviceProvider, options, providerWrapper)
+		})
 		if err != nil {
-			failedServices = append(failedServices, service)
+			return err
 		}
+		return verifyOutput(provider, options, paths)
+	}
+	refreshOptions := options.libraryOptions()
+	refreshOptions.Rules = rules
+	result, err := terraformer.Refresh(importContext, provider, args, refreshOptions)
+	if err != nil {
+		return err
 	}
 
-	// remove providers that failed to init their service
-	providersMapping.RemoveServices(failedServices)
-	providersMapping.ProcessResources(false)
-
-	return nil
-}
-
-func importFromPlan(providerMapping *terraformutils.ProvidersMapping, options ImportOptions, args []string) error {
 	plan := &ImportPlan{
-		Provider:         providerMapping.GetBaseProvider().GetName(),
+		Provider:         provider.GetName(),
 		Options:          options,
 		Args:             args,
-		ImportedResource: map[string][]terraformutils.Resource{},
-	}
-
-	resourcesByService := providerMapping.GetResourcesByService()
-	for service := range resourcesByService {
-		plan.ImportedResource[service] = append(plan.ImportedResource[service], resourcesByService[service]...)
+		ImportedResource: result.Resources,
+		ImpliedTypes:     result.ImpliedTypes,
 	}
-
 	if options.Plan {
-		path := Path(options.PathPattern, providerMapping.GetBaseProvider().GetName(), "terraformer", options.PathOutput)
+		path := Path(options.PathPattern, provider.GetName(), "terraformer", options.PathOutput)
 		return ExportPlanFile(plan, path, "plan.json")
 	}
 
-	return ImportFromPlan(providerMapping.GetBaseProvider(), plan)
+	return ImportFromPlan(provider, plan)
 }
 
-func initServiceResources(service string, provider terraformutils.ProviderGenerator,
-	options ImportOptions, providerWrapper *providerwrapper.ProviderWrapper) error {
-	log.Println(provider.GetName() + " importing... " + service)
-	err := provider.InitService(service, options.Verbose)
-	if err != nil {
-		log.Printf("%s error importing %s, err: %s\n", provider.GetName(), service, err)
-		return err
+// libraryOptions converts CLI options, provider specific ones are passed as args
+func (options ImportOptions) libraryOptions() terraformer.Options {
+	return terraformer.Options{
+		Resources:       options.Resources,
+		Excludes:        options.Excludes,
+		Filter:          options.Filter,
+		PathPattern:     options.PathPattern,
+		PathOutput:      options.PathOutput,
+		State:           options.State,
+		StateFormat:     options.StateFormat,
+		Bucket:          options.Bucket,
+		Connect:         options.Connect,
+		Compact:         options.Compact,
+		Output:          options.Output,
+		NoSort:          options.NoSort,
+		Verbose:         options.Verbose,
+		RetryCount:      options.RetryCount,
+		RetrySleepMs:    options.RetrySleepMs,
+		Timeout:         options.Timeout,
+		ServiceTimeout:  options.ServiceTimeout,
+		ProviderVersion: options.ProviderVersion,
+		Converter:       options.Converter,
+		StripDefaults:   options.StripDefaults,
+		DefaultTags:     options.DefaultTags,
+		Parameterize:    options.Parameterize,
+		DataSources:     options.DataSources,
+		Naming:          options.Naming,
+		Writer:          terraformoutput.FileWriter{},
+	}
+}
+
+func ImportFromPlan(provider terraformutils.ProviderGenerator, plan *ImportPlan) error {
+	result := &terraformer.Result{
+		Provider:     provider.GetName(),
+		Resources:    plan.ImportedResource,
+		ImpliedTypes: plan.ImpliedTypes,
+	}
+	// replayed plans have no implied types, without them state v4 falls back to attributes_flat
+	if result.ImpliedTypes == nil && plan.Options.StateFormat != terraformutils.StateFormatV3 {
+		if err := terraformer.LoadImpliedTypes(importContext, provider, result, plan.Options.libraryOptions()); err != nil {
+			return err
+		}
 	}
-	provider.GetService().ParseFilters(options.Filter)
-	err = provider.GetService().InitResources()
+	paths, err := writeStaged(plan.Options, func(libraryOptions terraformer.Options) error {
+		return terraformer.Write(importContext, provider, result, libraryOptions)
+	})
 	if err != nil {
-		log.Printf("%s error initializing resources in service %s, err: %s\n", provider.GetName(), service, err)
 		return err
 	}
-
-	provider.GetService().PopulateIgnoreKeys(providerWrapper)
-	provider.GetService().InitialCleanup()
-	log.Println(provider.GetName() + " done importing " + service)
-
-	return nil
+	if plan.Options.Heal {
+		return healOutput(provider, plan.Options, result)
+	}
+	return verifyOutput(provider, plan.Options, paths)
 }
 
-func ImportFromPlan(provider terraformutils.ProviderGenerator, plan *ImportPlan) error {
-	options := plan.Options
-	importedResource := plan.ImportedResource
-	isServicePath := strings.Contains(options.PathPattern, "{service}")
-
-	if options.Connect {
-		log.Println(provider.GetName() + " Connecting.... ")
-		importedResource = terraformutils.ConnectServices(importedResource, isServicePath, provider.GetResourceConnections())
+// writeStaged stages files next to the output and moves them in place once write succeeds,
+// so an interrupted import doesn't leave partial output. It returns the paths written.
+func writeStaged(options ImportOptions, write func(libraryOptions terraformer.Options) error) ([]string, error) {
+	libraryOptions := options.libraryOptions()
+	writer, err := terraformoutput.NewStagingWriter(filepath.Dir(filepath.Clean(options.PathOutput)))
+	if err != nil {
+		return nil, err
 	}
-
-	if !isServicePath {
-		var compactedResources []terraformutils.Resource
-		for _, resources := range importedResource {
-			compactedResources = append(compactedResources, resources...)
-		}
-		e := printService(provider, "", options, compactedResources, importedResource)
-		if e != nil {
-			return e
-		}
-	} else {
-		for serviceName, resources := range importedResource {
-			e := printService(provider, serviceName, options, resources, importedResource)
-			if e != nil {
-				return e
-			}
-		}
+	libraryOptions.Writer = writer
+	if err := write(libraryOptions); err != nil {
+		_ = writer.Discard()
+		return nil, err
 	}
-	return nil
+	if err := importContext.Err(); err != nil {
+		_ = writer.Discard()
+		return nil, err
+	}
+	return writer.Paths(), writer.Commit()
 }
 
-func printService(provider terraformutils.ProviderGenerator, serviceName string, options ImportOptions, resources []terraformutils.Resource, importedResource map[string][]terraformutils.Resource) error {
-	log.Println(provider.GetName() + " save " + serviceName)
-	// Print HCL files for Resources
-	path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
-	err := terraformoutput.OutputHclFiles(resources, provider, path, serviceName, options.Compact, options.Output, !options.NoSort)
+// verifyOutput plans the written directories when --verify is set
+func verifyOutput(provider terraformutils.ProviderGenerator, options ImportOptions, paths []string) error {
+	if !options.Verify {
+		return nil
+	}
+	dirs := terraformer.StateDirs(paths)
+	if len(dirs) == 0 {
+		return errors.New("--verify needs local state files, nothing to verify")
+	}
+	verification, err := terraformer.Verify(importContext, provider.GetName(), dirs, terraformer.VerifyOptions{Binary: options.VerifyBinary})
 	if err != nil {
 		return err
 	}
-	tfStateFile, err := terraformutils.PrintTfState(resources)
+	return reportVerification(provider, options, verification)
+}
+
+// healOutput fixes the diffs of the written directories and merges the fixes into --heal-rules
+func healOutput(provider terraformutils.ProviderGenerator, options ImportOptions, result *terraformer.Result) error {
+	healing, err := terraformer.Heal(importContext, provider, result, options.libraryOptions(), terraformer.HealOptions{
+		VerifyOptions: terraformer.VerifyOptions{Binary: options.VerifyBinary},
+		MaxIterations: options.HealMaxIterations,
+	})
 	if err != nil {
 		return err
 	}
-	// print or upload State file
-	if options.State == "bucket" {
-		log.Println(provider.GetName() + " upload tfstate to  bucket " + options.Bucket)
-		bucket := terraformoutput.BucketState{
-			Name: options.Bucket,
+	for _, fix := range healing.Fixes {
+		log.Printf("%s healed %s %s: %s\n", fix.Path, fix.Address, fix.Attribute, fix.Action)
+	}
+	if options.HealRules != "" && len(healing.Rules) > 0 {
+		rules, err := terraformutils.ReadRules(options.HealRules)
+		if errors.Is(err, fs.ErrNotExist) {
+			rules, err = terraformutils.Rules{}, nil
 		}
-		if err := bucket.BucketUpload(path, tfStateFile); err != nil {
+		if err != nil {
 			return err
 		}
-		// create Bucket file
-		if bucketStateDataFile, err := terraformutils.Print(bucket.BucketGetTfData(path), map[string]struct{}{}, options.Output, !options.NoSort); err == nil {
-			terraformoutput.PrintFile(path+"/bucket.tf", bucketStateDataFile)
-		}
-	} else {
-		if serviceName == "" {
-			log.Println(provider.GetName() + " save tfstate")
-		} else {
-			log.Println(provider.GetName() + " save tfstate for " + serviceName)
+		rules.Merge(healing.Rules)
+		data, err := rules.Marshal()
+		if err != nil {
+			return err
 		}
-		if err := os.WriteFile(path+"/terraform.tfstate", tfStateFile, os.ModePerm); err != nil {
+		if err := (terraformoutput.FileWriter{}).WriteFile(options.HealRules, data); err != nil {
 			return err
 		}
 	}
-	// Print hcl variables.tf
-	if serviceName != "" {
-		if options.Connect && len(provider.GetResourceConnections()[serviceName]) > 0 {
-			variables := map[string]map[string]map[string]interface{}{}
-			variables["data"] = map[string]map[string]interface{}{}
-			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
-			if options.State == "bucket" {
-				bucket := terraformoutput.BucketState{
-					Name: options.Bucket,
-				}
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
-					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
-						"backend": "gcs",
-						"config":  bucket.BucketGetTfData(strings.ReplaceAll(path, serviceName, k)),
-					}
-				}
-			} else {
-				for k := range provider.GetResourceConnections()[serviceName] {
-					if _, exist := importedResource[k]; !exist {
-						continue
-					}
-					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
-						"backend": "local",
-						"config": map[string]interface{}{
-							"path": strings.Repeat("../", strings.Count(path, "/")) + strings.ReplaceAll(path, serviceName, k) + "terraform.tfstate",
-						},
-					}
-				}
-			}
-			// create variables file
-			if len(provider.GetResourceConnections()[serviceName]) > 0 && options.Connect && len(variables["data"]["terraform_remote_state"]) > 0 {
-				variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort)
-				if err != nil {
-					return err
-				}
-				terraformoutput.PrintFile(path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile)
-			}
+	return reportVerification(provider, options, healing.Verification)
+}
+
+// reportVerification writes --verify-report and fails with --verify-strict
+func reportVerification(provider terraformutils.ProviderGenerator, options ImportOptions, verification *terraformer.Verification) error {
+	if options.VerifyReport != "" {
+		data, err := json.MarshalIndent(verification, "", "  ")
+		if err != nil {
+			return err
 		}
-	} else {
-		if options.Connect {
-			variables := map[string]map[string]map[string]interface{}{}
-			variables["data"] = map[string]map[string]interface{}{}
-			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
-			if options.State == "bucket" {
-				bucket := terraformoutput.BucketState{
-					Name: options.Bucket,
-				}
-				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
-					"backend": "gcs",
-					"config":  bucket.BucketGetTfData(path),
-				}
-			} else {
-				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
-					"backend": "local",
-					"config": map[string]interface{}{
-						"path": "terraform.tfstate",
-					},
-				}
-			}
-			// create variables file
-			if options.Connect {
-				variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort)
-				if err != nil {
-					return err
-				}
-				terraformoutput.PrintFile(path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile)
-			}
+		if err := (terraformoutput.FileWriter{}).WriteFile(options.VerifyReport, append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	if err := verification.Err(); err != nil {
+		if options.VerifyStrict {
+			return err
 		}
+		log.Println("WARN:", err)
+		return nil
 	}
+	log.Printf("%s verified %d directories without diffs\n", provider.GetName(), len(verification.Directories))
 	return nil
 }
 
 func Path(pathPattern, providerName, serviceName, output string) string {
-	return strings.NewReplacer(
-		"{provider}", providerName,
-		"{service}", serviceName,
-		"{output}", output,
-	).Replace(pathPattern)
+	return terraformer.Path(pathPattern, providerName, serviceName, output)
 }
 
 func listCmd(provider terraformutils.ProviderGenerator) *cobra.Command {
@@ -395,12 +340,7 @@ func listCmd(provider terraformutils.ProviderGenerator) *cobra.Command {
 }
 
 func providerServices(provider terraformutils.ProviderGenerator) []string {
-	var services []string
-	for k := range provider.GetSupportedService() {
-		services = append(services, k)
-	}
-	sort.Strings(services)
-	return services
+	return terraformer.SupportedServices(provider)
 }
 
 func baseProviderFlags(flag *pflag.FlagSet, options *ImportOptions, sampleRes, sampleFilters string) {
@@ -411,6 +351,7 @@ func baseProviderFlags(flag *pflag.FlagSet, options *ImportOptions, sampleRes, s
 	flag.StringVarP(&options.PathPattern, "path-pattern", "p", DefaultPathPattern, "{output}/{provider}/")
 	flag.StringVarP(&options.PathOutput, "path-output", "o", DefaultPathOutput, "")
 	flag.StringVarP(&options.State, "state", "s", DefaultState, "local or bucket")
+	flag.StringVarP(&options.StateFormat, "state-format", "", terraformer.DefaultStateFormat, "terraform state format, v4 or v3 for terraform 0.11")
 	flag.StringVarP(&options.Bucket, "bucket", "b", "", "gs://terraform-state")
 	flag.StringSliceVarP(&options.Filter, "filter", "f", []string{}, sampleFilters)
 	flag.BoolVarP(&options.Verbose, "verbose", "v", false, "")
@@ -418,4 +359,22 @@ func baseProviderFlags(flag *pflag.FlagSet, options *ImportOptions, sampleRes, s
 	flag.StringVarP(&options.Output, "output", "O", "hcl", "output format hcl or json")
 	flag.IntVarP(&options.RetryCount, "retry-number", "n", 5, "number of retries to perform when refresh fails")
 	flag.IntVarP(&options.RetrySleepMs, "retry-sleep-ms", "m", 300, "time in ms to sleep between retries")
+	flag.StringVarP(&options.Converter, "converter", "", terraformer.ConverterFlatmap, "flatmap or cty, cty keeps number and bool types and drops read-only attributes using the provider schema")
+	flag.BoolVarP(&options.Stream, "stream", "", false, "import and write services one at a time to bound memory")
+	flag.BoolVarP(&options.Verify, "verify", "", false, "run terraform plan on the output and report resources that would change")
+	flag.StringVarP(&options.VerifyBinary, "verify-binary", "", terraformer.DefaultVerifyBinary, "terraform or tofu executable used by --verify")
+	flag.BoolVarP(&options.VerifyStrict, "verify-strict", "", false, "fail the import when --verify finds diffs")
+	flag.StringVarP(&options.VerifyReport, "verify-report", "", "", "write the --verify results to this JSON file, e.g. verification.json")
+	flag.BoolVarP(&options.StripDefaults, "strip-defaults", "", false, "leave out attributes equal to the defaults planned by the provider")
+	flag.BoolVarP(&options.DefaultTags, "default-tags", "", false, "move the tags common to the resources of a directory to the provider, as default_tags for aws and default_labels for google")
+	flag.BoolVarP(&options.Parameterize, "parameterize", "", false, "replace account IDs, projects, regions and zones with variables and data sources, for aws and google")
+	flag.BoolVarP(&options.DataSources, "data-sources", "", false, "look up the resources linked to but not imported with data sources, needs --connect")
+	flag.StringVarP(&options.Naming, "naming", "", "", "template naming resources in snake_case instead of tfer-- names, e.g. {type}_{tags.Name|name|id}, renames are written to moved.tf")
+	flag.StringVarP(&options.Rules, "rules", "", "", "rules file overriding the generated configuration by resource type, e.g. rules.yaml")
+	flag.BoolVarP(&options.Heal, "heal", "", false, "fix the diffs found by --verify by removing attributes or ignoring their changes")
+	flag.IntVarP(&options.HealMaxIterations, "heal-max-iterations", "", terraformer.DefaultHealIterations, "how many times --heal rewrites the output")
+	flag.StringVarP(&options.HealRules, "heal-rules", "", "", "merge the fixes of --heal into this rules file, e.g. rules.yaml")
+	flag.DurationVarP(&options.Timeout, "timeout", "", 0, "abort the import after this duration, e.g. 30m")
+	flag.DurationVarP(&options.ServiceTimeout, "service-timeout", "", 0, "skip services that take longer than this duration to list, e.g. 5m")
+	flag.StringVarP(&options.ProviderVersion, "provider-version", "", "", "provider version constraint, e.g. \"~> 4.0\", highest installed version is used by default")
 }
@@ -0,0 +1,93 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest/fakeplugin"
+)
+
+func fakeBackend() fakeplugin.Backend {
+	return fakeplugin.Backend{
+		Objects: map[string]map[string]map[string]json.RawMessage{
+			"fake_network": {
+				"net-1": {
+					"name": json.RawMessage(`"main"`),
+					"cidr": json.RawMessage(`"10.0.0.0/16"`),
+					"tags": json.RawMessage(`{"env":"prod"}`),
+				},
+			},
+		},
+	}
+}
+
+// checkTypedState fails unless the state under output has typed attributes only
+func checkTypedState(t *testing.T, output string) {
+	t.Helper()
+	path := filepath.Join(output, "fake", "networks", "terraform.tfstate")
+	state, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(state), `"attributes": {`) || strings.Contains(string(state), `"attributes_flat"`) {
+		t.Errorf("state isn't typed:\n%s", state)
+	}
+}
+
+func TestImportWritesTypedState(t *testing.T) {
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeBackend())
+	output := t.TempDir()
+	options := ImportOptions{Resources: []string{"networks"}, PathPattern: DefaultPathPattern, PathOutput: output}
+	if err := Import(&terraformertest.FakeGenerator{}, options, args); err != nil {
+		t.Fatal(err)
+	}
+	checkTypedState(t, output)
+}
+
+func TestImportFromPlanFileWritesTypedState(t *testing.T) {
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeBackend())
+	output := t.TempDir()
+	options := ImportOptions{Resources: []string{"networks"}, PathPattern: DefaultPathPattern, PathOutput: output, Plan: true}
+	if err := Import(&terraformertest.FakeGenerator{}, options, args); err != nil {
+		t.Fatal(err)
+	}
+
+	// the plan file has no implied types, they are read from the provider schema
+	plan, err := LoadPlanfile(filepath.Join(output, "fake", "terraformer", "plan.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := &terraformertest.FakeGenerator{}
+	if err := provider.Init(plan.Args); err != nil {
+		t.Fatal(err)
+	}
+	for _, service := range plan.Options.Resources {
+		if err := provider.InitService(service, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ImportFromPlan(provider, plan); err != nil {
+		t.Fatal(err)
+	}
+	checkTypedState(t, output)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type ImportPlan struct {
@@ -30,6 +31,8 @@ type ImportPlan struct {
 	Options          ImportOptions
 	Args             []string
 	ImportedResource map[string][]terraformutils.Resource
+	// ImpliedTypes are carried from the refresh, plan files don't store them
+	ImpliedTypes map[string]cty.Type `json:"-"`
 }
 
 func newPlanCmd() *cobra.Command {
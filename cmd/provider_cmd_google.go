@@ -14,23 +14,48 @@
 package cmd
 
 import (
+	"errors"
 	"log"
 	"strings"
 
 	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
 	"github.com/spf13/cobra"
 )
 
 func newCmdGoogleImporter(options ImportOptions) *cobra.Command {
 	providerType := ""
+	organization := ""
+	folders := []string{}
 	cmd := &cobra.Command{
 		Use:   "google",
 		Short: "Import current state to Terraform configuration from Google Cloud",
 		Long:  "Import current state to Terraform configuration from Google Cloud",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if organization == "" && len(folders) == 0 && len(options.Projects) == 0 {
+				return errors.New("one of --projects, --organization or --folders must be set")
+			}
 			originalPathPattern := options.PathPattern
-			for _, project := range options.Projects {
+			projects := options.Projects
+			if organization != "" || len(folders) > 0 {
+				err := importGoogleHierarchy(options, providerType, organization, folders)
+				if err != nil {
+					return err
+				}
+				if len(projects) == 0 {
+					projects, err = gcp_terraforming.ListProjects(importContext, organization, folders)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			// hierarchy services are imported once above, not per project
+			options.Excludes = append(options.Excludes, gcp_terraforming.HierarchyServices...)
+			if len(projectServices(options)) == 0 {
+				return nil
+			}
+			for _, project := range projects {
 				for _, region := range options.Regions {
 					provider := newGoogleProvider()
 					options.PathPattern = originalPathPattern
@@ -50,10 +75,48 @@ func newCmdGoogleImporter(options ImportOptions) *cobra.Command {
 	cmd.PersistentFlags().StringSliceVarP(&options.Regions, "regions", "z", []string{"global"}, "europe-west1,")
 	cmd.PersistentFlags().StringSliceVarP(&options.Projects, "projects", "", []string{}, "")
 	cmd.PersistentFlags().StringVarP(&providerType, "provider-type", "", "", "beta")
-	_ = cmd.MarkPersistentFlagRequired("projects")
+	cmd.PersistentFlags().StringVarP(&organization, "organization", "", "", "organization id, imports the whole organization hierarchy")
+	cmd.PersistentFlags().StringSliceVarP(&folders, "folders", "", []string{}, "folder ids, imports the hierarchy below these folders")
 	return cmd
 }
 
+// importGoogleHierarchy imports organization, folders and projects services into {provider}/organization
+// or {provider}/folders
+func importGoogleHierarchy(options ImportOptions, providerType, organization string, folders []string) error {
+	resources := []string{}
+	for _, service := range gcp_terraforming.HierarchyServices {
+		if service == "organization" && organization == "" {
+			continue
+		}
+		if terraformerstring.ContainsString(options.Resources, "*") || terraformerstring.ContainsString(options.Resources, service) {
+			resources = append(resources, service)
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+	scope := "folders"
+	if organization != "" {
+		scope = "organization"
+	}
+	options.Resources = resources
+	options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}/{service}", "{provider}/"+scope+"/{service}")
+	provider := newGoogleProvider()
+	log.Println(provider.GetName() + " importing " + scope + " hierarchy")
+	return Import(provider, options, []string{"global", "", providerType, organization, strings.Join(folders, ",")})
+}
+
+// projectServices returns requested services left to import per project
+func projectServices(options ImportOptions) []string {
+	services := []string{}
+	for _, service := range options.Resources {
+		if service == "*" || !terraformerstring.ContainsString(options.Excludes, service) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
 func newGoogleProvider() terraformutils.ProviderGenerator {
 	return &gcp_terraforming.GCPProvider{}
 }
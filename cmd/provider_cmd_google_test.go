@@ -0,0 +1,57 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
+)
+
+func TestGoogleImporterRequiresScope(t *testing.T) {
+	cmd := newCmdGoogleImporter(ImportOptions{})
+	cmd.SetArgs([]string{"--resources=networks"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	if err == nil || err.Error() != "one of --projects, --organization or --folders must be set" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestGoogleImporterProjectsSkipHierarchyServices(t *testing.T) {
+	// hierarchy services need --organization or --folders, with --projects only nothing is imported
+	cmd := newCmdGoogleImporter(ImportOptions{})
+	cmd.SetArgs([]string{"--projects=p1,p2", "--resources=organization,folders,projects"})
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectServices(t *testing.T) {
+	options := ImportOptions{
+		Resources: []string{"networks", "folders", "projects"},
+		Excludes:  append([]string{"firewall"}, gcp_terraforming.HierarchyServices...),
+	}
+	if services := projectServices(options); !reflect.DeepEqual(services, []string{"networks"}) {
+		t.Errorf("got %v, want [networks]", services)
+	}
+	options.Resources = []string{"*"}
+	if services := projectServices(options); !reflect.DeepEqual(services, []string{"*"}) {
+		t.Errorf("got %v, want [*]", services)
+	}
+}
@@ -21,16 +21,17 @@ import (
 	"strings"
 
 	keycloak_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/keycloak"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/spf13/cobra"
 )
 
 const (
-	defaultKeycloakEndpoint              = "https://localhost:8443"
+	defaultKeycloakEndpoint              = terraformer.DefaultKeycloakEndpoint
 	defaultKeycloakBasePath              = "" // Override with `export KEYCLOAK_BASE_PATH=/auth` for the legacy version of Keycloak.
-	defaultKeycloakRealm                 = "master"
-	defaultKeycloakClientTimeout         = int64(30)
+	defaultKeycloakRealm                 = terraformer.DefaultKeycloakRealm
+	defaultKeycloakClientTimeout         = terraformer.DefaultKeycloakClientTimeout
 	defaultKeycloakTLSInsecureSkipVerify = false
 	defaultRedHatSSO                     = false
 )
@@ -18,12 +18,13 @@ import (
 	"os"
 
 	logzio_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/logzio"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/spf13/cobra"
 )
 
 const (
-	defaultBaseURL = "https://api.logz.io"
+	defaultBaseURL = terraformer.DefaultLogzioBaseURL
 )
 
 func newCmdLogzioImporter(options ImportOptions) *cobra.Command {
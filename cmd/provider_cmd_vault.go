@@ -14,6 +14,11 @@
 package cmd
 
 import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
 	vault_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/vault"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -21,17 +26,39 @@ import (
 )
 
 func newCmdVaultImporter(options ImportOptions) *cobra.Command {
-	var token, address string
+	var token, address, namespace string
+	var maxDepth int
+	var secretsAsVariables, recursiveNamespaces bool
 	cmd := &cobra.Command{
 		Use:   "vault",
 		Short: "Import current state to Terraform configuration from Vault",
 		Long:  "Import current state to Terraform configuration from Vault",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			provider := newVaultProvider()
-			err := Import(provider, options, []string{address, token})
+			if !recursiveNamespaces {
+				provider := newVaultProvider()
+				err := Import(provider, options, []string{address, token, strconv.Itoa(maxDepth), strconv.FormatBool(secretsAsVariables), namespace})
+				if err != nil {
+					return err
+				}
+				return nil
+			}
+			namespaces, err := vault_terraforming.ListNamespaces(vaultParam(address, "VAULT_ADDR"), vaultParam(token, "VAULT_TOKEN"), vaultParam(namespace, "VAULT_NAMESPACE"))
 			if err != nil {
 				return err
 			}
+			originalPathPattern := options.PathPattern
+			for _, ns := range namespaces {
+				provider := newVaultProvider()
+				log.Println(provider.GetName() + " importing namespace " + ns)
+				options.PathPattern = originalPathPattern
+				if ns != "" {
+					options.PathPattern = strings.ReplaceAll(options.PathPattern, "{provider}", "{provider}/"+ns)
+				}
+				err := Import(provider, options, []string{address, token, strconv.Itoa(maxDepth), strconv.FormatBool(secretsAsVariables), ns})
+				if err != nil {
+					return err
+				}
+			}
 			return nil
 		},
 	}
@@ -39,10 +66,22 @@ func newCmdVaultImporter(options ImportOptions) *cobra.Command {
 	cmd.AddCommand(listCmd(newVaultProvider()))
 	cmd.PersistentFlags().StringVarP(&address, "address", "a", "", "env param VAULT_ADDR")
 	cmd.PersistentFlags().StringVarP(&token, "token", "t", "", "env param VAULT_TOKEN")
+	cmd.PersistentFlags().IntVar(&maxDepth, "max-depth", 0, "maximum folder depth to list kv secrets, 0 for unlimited")
+	cmd.PersistentFlags().StringVarP(&namespace, "namespace", "", "", "env param VAULT_NAMESPACE")
+	cmd.PersistentFlags().BoolVar(&recursiveNamespaces, "recursive-namespaces", false, "import the namespace and every nested namespace into its own directory")
+	cmd.PersistentFlags().BoolVar(&secretsAsVariables, "secrets-as-variables", false, "replace kv secret data with sensitive variables instead of writing values to HCL and state")
 	baseProviderFlags(cmd.PersistentFlags(), &options, "", "")
 	return cmd
 }
 
+// vaultParam returns value, or the env param when value is not set
+func vaultParam(value, env string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(env)
+}
+
 func newVaultProvider() terraformutils.ProviderGenerator {
 	return &vault_terraforming.Provider{}
 }
@@ -15,6 +15,11 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/spf13/cobra"
 )
@@ -32,8 +37,11 @@ func NewCmdRoot() *cobra.Command {
 }
 
 func Execute() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	importContext = ctx
 	cmd := NewCmdRoot()
-	return cmd.Execute()
+	return cmd.ExecuteContext(ctx)
 }
 
 func providerImporterSubcommands() []func(options ImportOptions) *cobra.Command {
@@ -99,6 +99,10 @@ func (p *AliCloudProvider) GetName() string {
 	return "alicloud"
 }
 
+func (p *AliCloudProvider) GetSource() string {
+	return "aliyun/alicloud"
+}
+
 // InitService Initializes the AliCloud service
 func (p *AliCloudProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
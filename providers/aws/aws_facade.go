@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -20,6 +21,10 @@ func (s *AwsFacade) SetVerbose(verbose bool) {
 	s.service.SetVerbose(verbose)
 }
 
+func (s *AwsFacade) SetContext(ctx context.Context) {
+	s.service.SetContext(ctx)
+}
+
 func (s *AwsFacade) ParseFilters(rawFilters []string) {
 	s.service.ParseFilters(rawFilters)
 }
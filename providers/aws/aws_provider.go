@@ -15,6 +15,7 @@
 package aws
 
 import (
+	"context"
 	"os"
 	"strconv"
 
@@ -165,6 +166,66 @@ func (p AWSProvider) GetProviderData(arg ...string) map[string]interface{} {
 	}
 }
 
+// GetDefaultTags lifts tags into default_tags, tags_all is computed from them by the provider
+func (p AWSProvider) GetDefaultTags() terraformutils.DefaultTags {
+	return terraformutils.DefaultTags{
+		Attribute:    "tags",
+		Dropped:      []string{"tags_all"},
+		ProviderPath: []string{"default_tags", "tags"},
+	}
+}
+
+// GetParameters replaces the account ID and the region with data sources, the region of the
+// provider is read from var.region
+func (p *AWSProvider) GetParameters(ctx context.Context) ([]terraformutils.Parameter, error) {
+	service := &AWSService{}
+	service.SetContext(ctx)
+	service.SetArgs(map[string]interface{}{
+		"region":  p.region,
+		"profile": p.profile,
+	})
+	config, err := service.generateConfig()
+	if err != nil {
+		return nil, err
+	}
+	account, err := service.getAccountNumber(config)
+	if err != nil {
+		return nil, err
+	}
+	parameters := []terraformutils.Parameter{{
+		Value:       *account,
+		Reference:   "${data.aws_caller_identity.current.account_id}",
+		DataSources: []string{"aws_caller_identity"},
+	}}
+	region := p.region
+	if region == GlobalRegion {
+		region = MainRegionPublicPartition
+	}
+	if region != NoRegion {
+		parameters = append(parameters, terraformutils.Parameter{
+			Value:             region,
+			Reference:         "${data.aws_region.current.name}",
+			ProviderReference: "${var.region}",
+			Variables:         map[string]string{"region": region},
+			DataSources:       []string{"aws_region"},
+		})
+	}
+	return parameters, nil
+}
+
+// GetDataSources looks up networking resources of connections that aren't imported
+func (p AWSProvider) GetDataSources() map[string]terraformutils.DataSource {
+	return map[string]terraformutils.DataSource{
+		"customer_gateway": {Type: "aws_customer_gateway", Argument: "id", Attribute: "id"},
+		"route_table":      {Type: "aws_route_table", Argument: "route_table_id", Attribute: "id"},
+		"sg":               {Type: "aws_security_group", Argument: "id", Attribute: "id"},
+		"subnet":           {Type: "aws_subnet", Argument: "id", Attribute: "id"},
+		"transit_gateway":  {Type: "aws_ec2_transit_gateway", Argument: "id", Attribute: "id"},
+		"vpc":              {Type: "aws_vpc", Argument: "id", Attribute: "id"},
+		"vpn_gateway":      {Type: "aws_vpn_gateway", Argument: "id", Attribute: "id"},
+	}
+}
+
 func (p *AWSProvider) GetConfig() cty.Value {
 	if p.region != GlobalRegion {
 		return cty.ObjectVal(map[string]cty.Value{
@@ -218,6 +279,10 @@ func (p *AWSProvider) GetName() string {
 	return "aws"
 }
 
+func (p *AWSProvider) GetSource() string {
+	return "hashicorp/aws"
+}
+
 func (p *AWSProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
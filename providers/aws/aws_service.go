@@ -15,7 +15,6 @@
 package aws
 
 import (
-	"context"
 	"os"
 	"regexp"
 
@@ -50,7 +49,7 @@ func (s *AWSService) generateConfig() (aws.Config, error) {
 		baseConfig.ClientLogMode = aws.LogRequestWithBody & aws.LogResponseWithBody
 	}
 
-	creds, e := baseConfig.Credentials.Retrieve(context.TODO())
+	creds, e := baseConfig.Credentials.Retrieve(s.Context())
 
 	if e != nil {
 		return baseConfig, e
@@ -81,7 +80,7 @@ func (s *AWSService) buildBaseConfig() (aws.Config, error) {
 	loadOptions = append(loadOptions, config.WithAssumeRoleCredentialOptions(func(options *stscreds.AssumeRoleOptions) {
 		options.TokenProvider = stscreds.StdinTokenProvider
 	}))
-	return config.LoadDefaultConfig(context.TODO(), loadOptions...)
+	return config.LoadDefaultConfig(s.Context(), loadOptions...)
 }
 
 // for CF interpolation and IAM Policy variables
@@ -91,7 +90,7 @@ func (*AWSService) escapeAwsInterpolation(str string) string {
 
 func (s *AWSService) getAccountNumber(config aws.Config) (*string, error) {
 	stsSvc := sts.NewFromConfig(config)
-	identity, err := stsSvc.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+	identity, err := stsSvc.GetCallerIdentity(s.Context(), &sts.GetCallerIdentityInput{})
 	if err != nil {
 		return nil, err
 	}
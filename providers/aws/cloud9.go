@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/cloud9"
 	"github.com/aws/aws-sdk-go-v2/service/cloud9/types"
@@ -34,12 +32,12 @@ func (g *Cloud9Generator) InitResources() error {
 		return e
 	}
 	svc := cloud9.NewFromConfig(config)
-	output, err := svc.ListEnvironments(context.TODO(), &cloud9.ListEnvironmentsInput{})
+	output, err := svc.ListEnvironments(g.Context(), &cloud9.ListEnvironmentsInput{})
 	if err != nil {
 		return err
 	}
 	for _, environmentID := range output.EnvironmentIds {
-		details, _ := svc.DescribeEnvironmentStatus(context.TODO(), &cloud9.DescribeEnvironmentStatusInput{
+		details, _ := svc.DescribeEnvironmentStatus(g.Context(), &cloud9.DescribeEnvironmentStatusInput{
 			EnvironmentId: &environmentID,
 		})
 		if details.Status == types.EnvironmentStatusError ||
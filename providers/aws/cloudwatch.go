@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchevents"
@@ -56,7 +54,7 @@ func (g *CloudWatchGenerator) InitResources() error {
 func (g *CloudWatchGenerator) createMetricAlarms(cloudwatchSvc *cloudwatch.Client) error {
 	var nextToken *string
 	for {
-		output, err := cloudwatchSvc.DescribeAlarms(context.TODO(), &cloudwatch.DescribeAlarmsInput{
+		output, err := cloudwatchSvc.DescribeAlarms(g.Context(), &cloudwatch.DescribeAlarmsInput{
 			NextToken: nextToken,
 		})
 		if err != nil {
@@ -81,7 +79,7 @@ func (g *CloudWatchGenerator) createMetricAlarms(cloudwatchSvc *cloudwatch.Clien
 func (g *CloudWatchGenerator) createDashboards(cloudwatchSvc *cloudwatch.Client) error {
 	var nextToken *string
 	for {
-		output, err := cloudwatchSvc.ListDashboards(context.TODO(), &cloudwatch.ListDashboardsInput{
+		output, err := cloudwatchSvc.ListDashboards(g.Context(), &cloudwatch.ListDashboardsInput{
 			NextToken: nextToken,
 		})
 		if err != nil {
@@ -106,7 +104,7 @@ func (g *CloudWatchGenerator) createDashboards(cloudwatchSvc *cloudwatch.Client)
 func (g *CloudWatchGenerator) createRules(cloudwatcheventsSvc *cloudwatchevents.Client) error {
 	var listRulesNextToken *string
 	for {
-		output, err := cloudwatcheventsSvc.ListRules(context.TODO(), &cloudwatchevents.ListRulesInput{
+		output, err := cloudwatcheventsSvc.ListRules(g.Context(), &cloudwatchevents.ListRulesInput{
 			NextToken: listRulesNextToken,
 		})
 		if err != nil {
@@ -122,7 +120,7 @@ func (g *CloudWatchGenerator) createRules(cloudwatcheventsSvc *cloudwatchevents.
 
 			var listTargetsNextToken *string
 			for {
-				targetResponse, err := cloudwatcheventsSvc.ListTargetsByRule(context.TODO(), &cloudwatchevents.ListTargetsByRuleInput{
+				targetResponse, err := cloudwatcheventsSvc.ListTargetsByRule(g.Context(), &cloudwatchevents.ListTargetsByRuleInput{
 					Rule:      rule.Name,
 					NextToken: listTargetsNextToken,
 				})
@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/aws/aws-sdk-go-v2/service/ecrpublic"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
@@ -40,7 +38,7 @@ func (g *EcrPublicGenerator) InitResources() error {
 
 	p := ecrpublic.NewDescribeRepositoriesPaginator(svc, &ecrpublic.DescribeRepositoriesInput{})
 	for p.HasMorePages() {
-		page, e := p.NextPage(context.TODO())
+		page, e := p.NextPage(g.Context())
 		if e != nil {
 			return e
 		}
@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/iot"
 )
@@ -51,7 +49,7 @@ func (g *IotGenerator) InitResources() error {
 }
 
 func (g *IotGenerator) loadThingTypes(svc *iot.Client) error {
-	output, err := svc.ListThingTypes(context.TODO(), &iot.ListThingTypesInput{})
+	output, err := svc.ListThingTypes(g.Context(), &iot.ListThingTypesInput{})
 	if err != nil {
 		return err
 	}
@@ -72,7 +70,7 @@ func (g *IotGenerator) loadThingTypes(svc *iot.Client) error {
 }
 
 func (g *IotGenerator) loadThings(svc *iot.Client) error {
-	output, err := svc.ListThings(context.TODO(), &iot.ListThingsInput{})
+	output, err := svc.ListThings(g.Context(), &iot.ListThingsInput{})
 	if err != nil {
 		return err
 	}
@@ -93,7 +91,7 @@ func (g *IotGenerator) loadThings(svc *iot.Client) error {
 }
 
 func (g *IotGenerator) loadTopicRules(svc *iot.Client) error {
-	output, err := svc.ListTopicRules(context.TODO(), &iot.ListTopicRulesInput{})
+	output, err := svc.ListTopicRules(g.Context(), &iot.ListTopicRulesInput{})
 	if err != nil {
 		return err
 	}
@@ -109,7 +107,7 @@ func (g *IotGenerator) loadTopicRules(svc *iot.Client) error {
 }
 
 func (g *IotGenerator) loadRoleAliases(svc *iot.Client) error {
-	output, err := svc.ListRoleAliases(context.TODO(), &iot.ListRoleAliasesInput{})
+	output, err := svc.ListRoleAliases(g.Context(), &iot.ListRoleAliasesInput{})
 	if err != nil {
 		return err
 	}
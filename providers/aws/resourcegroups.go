@@ -15,8 +15,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroups"
 )
@@ -36,7 +34,7 @@ func (g *ResourceGroupsGenerator) InitResources() error {
 	p := resourcegroups.NewListGroupsPaginator(svc, &resourcegroups.ListGroupsInput{})
 	var resources []terraformutils.Resource
 	for p.HasMorePages() {
-		page, err := p.NextPage(context.TODO())
+		page, err := p.NextPage(g.Context())
 		if err != nil {
 			return err
 		}
@@ -1,8 +1,6 @@
 package aws
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/aws/aws-sdk-go-v2/service/swf"
 	"github.com/aws/aws-sdk-go-v2/service/swf/types"
@@ -22,7 +20,7 @@ func (g *SWFGenerator) InitResources() error {
 	for _, status := range regStatuses {
 		p := swf.NewListDomainsPaginator(svc, &swf.ListDomainsInput{RegistrationStatus: status})
 		for p.HasMorePages() {
-			page, err := p.NextPage(context.TODO())
+			page, err := p.NextPage(g.Context())
 			if err != nil {
 				return err
 			}
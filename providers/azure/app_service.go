@@ -1,7 +1,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -17,7 +16,7 @@ type AppServiceGenerator struct {
 
 func (g AppServiceGenerator) listApps() ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
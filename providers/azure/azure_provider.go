@@ -137,6 +137,10 @@ func (p *AzureProvider) GetName() string {
 	return "azurerm"
 }
 
+func (p *AzureProvider) GetSource() string {
+	return "hashicorp/azurerm"
+}
+
 func (p *AzureProvider) GetProviderData(arg ...string) map[string]interface{} {
 	version := providerwrapper.GetProviderVersion(p.GetName())
 	if strings.Contains(version, "v2.") {
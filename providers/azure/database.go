@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -33,7 +32,7 @@ type DatabasesGenerator struct {
 }
 
 func (g *DatabasesGenerator) getMariaDBServers() ([]mariadb.Server, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -78,7 +77,7 @@ func (g *DatabasesGenerator) createMariaDBServerResources(servers []mariadb.Serv
 
 func (g *DatabasesGenerator) createMariaDBConfigurationResources(servers []mariadb.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -111,7 +110,7 @@ func (g *DatabasesGenerator) createMariaDBConfigurationResources(servers []maria
 
 func (g *DatabasesGenerator) createMariaDBDatabaseResources(servers []mariadb.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -144,7 +143,7 @@ func (g *DatabasesGenerator) createMariaDBDatabaseResources(servers []mariadb.Se
 
 func (g *DatabasesGenerator) createMariaDBFirewallRuleResources(servers []mariadb.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -176,7 +175,7 @@ func (g *DatabasesGenerator) createMariaDBFirewallRuleResources(servers []mariad
 
 func (g *DatabasesGenerator) createMariaDBVirtualNetworkRuleResources(servers []mariadb.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -211,7 +210,7 @@ func (g *DatabasesGenerator) createMariaDBVirtualNetworkRuleResources(servers []
 }
 
 func (g *DatabasesGenerator) getMySQLServers() ([]mysql.Server, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -257,7 +256,7 @@ func (g *DatabasesGenerator) createMySQLServerResources(servers []mysql.Server)
 
 func (g *DatabasesGenerator) createMySQLConfigurationResources(servers []mysql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -290,7 +289,7 @@ func (g *DatabasesGenerator) createMySQLConfigurationResources(servers []mysql.S
 
 func (g *DatabasesGenerator) createMySQLDatabaseResources(servers []mysql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -322,7 +321,7 @@ func (g *DatabasesGenerator) createMySQLDatabaseResources(servers []mysql.Server
 
 func (g *DatabasesGenerator) createMySQLFirewallRuleResources(servers []mysql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -355,7 +354,7 @@ func (g *DatabasesGenerator) createMySQLFirewallRuleResources(servers []mysql.Se
 
 func (g *DatabasesGenerator) createMySQLVirtualNetworkRuleResources(servers []mysql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -393,7 +392,7 @@ func (g *DatabasesGenerator) createMySQLVirtualNetworkRuleResources(servers []my
 }
 
 func (g *DatabasesGenerator) getPostgreSQLServers() ([]postgresql.Server, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -440,7 +439,7 @@ func (g *DatabasesGenerator) createPostgreSQLServerResources(servers []postgresq
 
 func (g *DatabasesGenerator) createPostgreSQLDatabaseResources(servers []postgresql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -472,7 +471,7 @@ func (g *DatabasesGenerator) createPostgreSQLDatabaseResources(servers []postgre
 
 func (g *DatabasesGenerator) createPostgreSQLConfigurationResources(servers []postgresql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -503,7 +502,7 @@ func (g *DatabasesGenerator) createPostgreSQLConfigurationResources(servers []po
 
 func (g *DatabasesGenerator) createPostgreSQLFirewallRuleResources(servers []postgresql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -535,7 +534,7 @@ func (g *DatabasesGenerator) createPostgreSQLFirewallRuleResources(servers []pos
 
 func (g *DatabasesGenerator) createPostgreSQLVirtualNetworkRuleResources(servers []postgresql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -572,7 +571,7 @@ func (g *DatabasesGenerator) createPostgreSQLVirtualNetworkRuleResources(servers
 
 func (g *DatabasesGenerator) getSQLServers() ([]sql.Server, error) {
 	var servers []sql.Server
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -624,7 +623,7 @@ func (g *DatabasesGenerator) createSQLServerResources(servers []sql.Server) ([]t
 
 func (g *DatabasesGenerator) createSQLDatabaseResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -656,7 +655,7 @@ func (g *DatabasesGenerator) createSQLDatabaseResources(servers []sql.Server) ([
 
 func (g *DatabasesGenerator) createSQLFirewallRuleResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -688,7 +687,7 @@ func (g *DatabasesGenerator) createSQLFirewallRuleResources(servers []sql.Server
 
 func (g *DatabasesGenerator) createSQLVirtualNetworkRuleResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -725,7 +724,7 @@ func (g *DatabasesGenerator) createSQLVirtualNetworkRuleResources(servers []sql.
 
 func (g *DatabasesGenerator) createSQLElasticPoolResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -757,7 +756,7 @@ func (g *DatabasesGenerator) createSQLElasticPoolResources(servers []sql.Server)
 
 func (g *DatabasesGenerator) createSQLFailoverResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -796,7 +795,7 @@ func (g *DatabasesGenerator) createSQLFailoverResources(servers []sql.Server) ([
 
 func (g *DatabasesGenerator) createSQLADAdministratorResources(servers []sql.Server) ([]terraformutils.Resource, error) {
 	var resources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	Authorizer := g.Args["authorizer"].(autorest.Authorizer)
@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/databricks/mgmt/2018-04-01/databricks"
@@ -33,7 +32,7 @@ func (az *DatabricksGenerator) listWorkspaces() ([]databricks.Workspace, error)
 		iterator databricks.WorkspaceListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
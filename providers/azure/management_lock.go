@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
@@ -33,7 +32,7 @@ func (az *ManagementLockGenerator) listResources() ([]locks.ManagementLockObject
 		iterator locks.ManagementLockListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListAtResourceGroupLevelComplete(ctx, resourceGroup, "")
 	} else {
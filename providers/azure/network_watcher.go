@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-03-01/network"
@@ -33,7 +32,7 @@ func (az *NetworkWatcherGenerator) listResources() ([]network.Watcher, error) {
 		resources network.WatcherListResult
 		err       error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		resources, err = client.List(ctx, resourceGroup)
 	} else {
@@ -53,7 +52,7 @@ func (az *NetworkWatcherGenerator) appendFlowLogs(parent *network.Watcher, resou
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := network.NewFlowLogsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	iterator, err := client.ListComplete(ctx, resourceGroupID.ResourceGroup, *parent.Name)
 	if err != nil {
 		return err
@@ -73,7 +72,7 @@ func (az *NetworkWatcherGenerator) appendPacketCaptures(parent *network.Watcher,
 	subscriptionID, _, authorizer, resourceManagerEndpoint := az.getClientArgs()
 	client := network.NewPacketCapturesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	client.Authorizer = authorizer
-	ctx := context.Background()
+	ctx := az.Context()
 	resources, err := client.List(ctx, resourceGroupID.ResourceGroup, *parent.Name)
 	if err != nil {
 		return err
@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/purview/mgmt/2021-07-01/purview"
@@ -33,7 +32,7 @@ func (az *PurviewGenerator) listAccounts() ([]purview.Account, error) {
 		iterator purview.AccountListIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup, "")
 	} else {
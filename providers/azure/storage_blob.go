@@ -78,7 +78,7 @@ func (g StorageBlobGenerator) getBlobsFromContainer(ctx context.Context, account
 
 func (g StorageBlobGenerator) listStorageBlobs() ([]terraformutils.Resource, error) {
 	var storageBlobsResources []terraformutils.Resource
-	ctx := context.Background()
+	ctx := g.Context()
 
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
@@ -1,7 +1,6 @@
 package azure
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-04-01/storage"
@@ -34,7 +33,7 @@ func (g StorageContainerGenerator) ListBlobContainers() ([]terraformutils.Resour
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	blobContainersClient := storage.NewBlobContainersClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	blobContainersClient.Authorizer = g.Args["authorizer"].(autorest.Authorizer)
-	ctx := context.Background()
+	ctx := g.Context()
 
 	accounts, err := g.getStorageAccounts()
 	if err != nil {
@@ -76,7 +75,7 @@ func (g StorageContainerGenerator) ListBlobContainers() ([]terraformutils.Resour
 }
 
 func (g *StorageContainerGenerator) getStorageAccounts() ([]storage.Account, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	subscriptionID := g.Args["config"].(authentication.Config).SubscriptionID
 	resourceManagerEndpoint := g.Args["config"].(authentication.Config).CustomResourceManagerEndpoint
 	accountsClient := storage.NewAccountsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
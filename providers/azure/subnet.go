@@ -15,7 +15,6 @@
 package azure
 
 import (
-	"context"
 	"log"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-02-01/network"
@@ -36,7 +35,7 @@ func (az *SubnetGenerator) lisSubnets() ([]network.Subnet, error) {
 		subnetIter network.SubnetListResultIterator
 		err        error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		vnetIter, err = vnetClient.ListComplete(ctx, resourceGroup)
 	} else {
@@ -126,7 +125,7 @@ func (az *SubnetGenerator) appendServiceEndpointPolicies() error {
 		iterator network.ServiceEndpointPolicyListResultIterator
 		err      error
 	)
-	ctx := context.Background()
+	ctx := az.Context()
 	if resourceGroup != "" {
 		iterator, err = client.ListByResourceGroupComplete(ctx, resourceGroup)
 	} else {
@@ -63,6 +63,10 @@ func (p *AzureADProvider) GetName() string {
 	return "azuread"
 }
 
+func (p *AzureADProvider) GetSource() string {
+	return "hashicorp/azuread"
+}
+
 func (p *AzureADProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
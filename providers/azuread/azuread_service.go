@@ -15,7 +15,6 @@
 package azuread
 
 import (
-	"context"
 	"fmt"
 	"log"
 
@@ -36,7 +35,7 @@ type ServiceGenerator interface {
 
 func (az *AzureADService) getAuthorizer() (auth.Authorizer, error) {
 	environment := environments.Global
-	ctx := context.Background()
+	ctx := az.Context()
 	tenantID := az.Args["tenant_id"].(string)
 	clientID := az.Args["client_id"].(string)
 	clientSecret := az.Args["client_secret"].(string)
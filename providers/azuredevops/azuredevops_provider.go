@@ -54,6 +54,10 @@ func (p *AzureDevOpsProvider) GetName() string {
 	return "azuredevops"
 }
 
+func (p *AzureDevOpsProvider) GetSource() string {
+	return "microsoft/azuredevops"
+}
+
 func (p *AzureDevOpsProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -32,6 +32,10 @@ func (p *CloudflareProvider) GetName() string {
 	return "cloudflare"
 }
 
+func (p *CloudflareProvider) GetSource() string {
+	return "cloudflare/cloudflare"
+}
+
 func (p *CloudflareProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -52,6 +52,10 @@ func (p *CommercetoolsProvider) GetName() string {
 	return "commercetools"
 }
 
+func (p *CommercetoolsProvider) GetSource() string {
+	return "labd/commercetools"
+}
+
 func (p *CommercetoolsProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
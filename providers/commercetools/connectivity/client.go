@@ -8,7 +8,7 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
-func (c *Config) NewClient() *commercetools.Client {
+func (c *Config) NewClient(ctx context.Context) *commercetools.Client {
 	oauth2Config := &clientcredentials.Config{
 		ClientID:     c.ClientID,
 		ClientSecret: c.ClientSecret,
@@ -16,7 +16,7 @@ func (c *Config) NewClient() *commercetools.Client {
 		TokenURL:     c.TokenURL,
 	}
 
-	httpClient := oauth2Config.Client(context.TODO())
+	httpClient := oauth2Config.Client(ctx)
 
 	return commercetools.New(&commercetools.Config{
 		ProjectKey:  c.ProjectKey,
@@ -15,8 +15,6 @@
 package commercetools
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/providers/commercetools/connectivity"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/labd/commercetools-go-sdk/commercetools"
@@ -36,9 +34,9 @@ func (g *TaxCategoryGenerator) InitResources() error {
 		BaseURL:      g.GetArgs()["base_url"].(string),
 	}
 
-	client := cfg.NewClient()
+	client := cfg.NewClient(g.Context())
 
-	categories, err := client.TaxCategoryQuery(context.Background(), &commercetools.QueryInput{})
+	categories, err := client.TaxCategoryQuery(g.Context(), &commercetools.QueryInput{})
 	if err != nil {
 		return err
 	}
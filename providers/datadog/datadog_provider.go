@@ -125,6 +125,10 @@ func (p *DatadogProvider) GetName() string {
 	return "datadog"
 }
 
+func (p *DatadogProvider) GetSource() string {
+	return "datadog/datadog"
+}
+
 // GetConfig return map of provider config for Datadog
 func (p *DatadogProvider) GetConfig() cty.Value {
 	return cty.ObjectVal(map[string]cty.Value{
@@ -39,6 +39,10 @@ func (p *DigitalOceanProvider) GetName() string {
 	return "digitalocean"
 }
 
+func (p *DigitalOceanProvider) GetSource() string {
+	return "digitalocean/digitalocean"
+}
+
 func (p *DigitalOceanProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
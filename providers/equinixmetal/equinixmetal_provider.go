@@ -45,6 +45,10 @@ func (p *EquinixMetalProvider) GetName() string {
 	return "metal"
 }
 
+func (p *EquinixMetalProvider) GetSource() string {
+	return "equinix/metal"
+}
+
 func (p *EquinixMetalProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
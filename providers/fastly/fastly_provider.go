@@ -45,6 +45,10 @@ func (p *FastlyProvider) GetName() string {
 	return "fastly"
 }
 
+func (p *FastlyProvider) GetSource() string {
+	return "fastly/fastly"
+}
+
 func (p *FastlyProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
@@ -62,7 +62,7 @@ func (g BackendBucketsGenerator) createResources(ctx context.Context, backendBuc
 // from each backendBuckets create 1 TerraformResource
 // Need backendBuckets name as ID for terraform resource
 func (g *BackendBucketsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
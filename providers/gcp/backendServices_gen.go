@@ -61,7 +61,7 @@ func (g BackendServicesGenerator) createResources(ctx context.Context, backendSe
 // from each backendServices create 1 TerraformResource
 // Need backendServices name as ID for terraform resource
 func (g *BackendServicesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
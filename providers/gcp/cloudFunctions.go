@@ -96,7 +96,7 @@ func (g CloudFunctionsGenerator) createCloudFunctions2ndGenResources(ctx context
 // from each CloudFunctions create 1 TerraformResource
 // Need CloudFunctions name as ID for terraform resource
 func (g *CloudFunctionsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	cloudfunctionsService, err := cloudfunctions.NewService(ctx)
 	if err != nil {
 		return err
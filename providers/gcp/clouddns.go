@@ -94,7 +94,7 @@ func (g CloudDNSGenerator) createRecordsResources(ctx context.Context, svc *dns.
 // create terraform resource for each zone + each record
 func (g *CloudDNSGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
+	ctx := g.Context()
 	svc, err := dns.NewService(ctx)
 	if err != nil {
 		return err
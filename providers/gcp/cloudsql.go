@@ -15,8 +15,6 @@
 package gcp
 
 import (
-	"context"
-
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
@@ -86,7 +84,7 @@ func (g *CloudSQLGenerator) loadDBs(svc *sqladmin.Service, instanceName, project
 // Need dbinstance name as ID for terraform resource
 func (g *CloudSQLGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
+	ctx := g.Context()
 	svc, err := sqladmin.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g *CloudTaskGenerator) loadCloudTaskQueues(ctx context.Context, client *cl
 // Generate TerraformResources from GCP API,
 // from each cloud task queue create 1 TerraformResource
 func (g *CloudTaskGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := cloudtasks.NewClient(ctx)
 	if err != nil {
 		return err
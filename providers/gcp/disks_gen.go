@@ -64,7 +64,7 @@ func (g DisksGenerator) createResources(ctx context.Context, disksList *compute.
 // from each disks create 1 TerraformResource
 // Need disks name as ID for terraform resource
 func (g *DisksGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,117 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+type FoldersGenerator struct {
+	GCPService
+}
+
+// Generate folders of the hierarchy with their IAM members and organization policies
+func (g *FoldersGenerator) InitResources() error {
+	roots := hierarchyRoots(g.GetArgs()["organization"].(string), g.GetArgs()["folders"].([]string))
+	if len(roots) == 0 {
+		return errNoHierarchy
+	}
+	ctx := g.Context()
+
+	crm, err := crmv3.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	crmv1, err := cloudresourcemanager.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	folders, err := listFolders(ctx, crm, roots)
+	if err != nil {
+		return err
+	}
+	for _, folder := range folders {
+		if folder.State != "ACTIVE" {
+			continue
+		}
+		g.Resources = append(g.Resources, terraformutils.NewResource(
+			folder.Name,
+			strings.TrimPrefix(folder.Name, "folders/")+"_"+folder.DisplayName,
+			"google_folder",
+			g.ProviderName,
+			map[string]string{
+				"parent":       folder.Parent,
+				"display_name": folder.DisplayName,
+			},
+			[]string{},
+			map[string]interface{}{},
+		))
+
+		policy, err := crm.Folders.GetIamPolicy(folder.Name, &crmv3.GetIamPolicyRequest{}).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		g.Resources = append(g.Resources, createHierarchyIamMembers(policy, "google_folder_iam_member", g.ProviderName, "folder", folder.Name)...)
+
+		constraints, err := listOrgPolicies(ctx, crmv1.Folders.ListOrgPolicies(folder.Name, &cloudresourcemanager.ListOrgPoliciesRequest{}))
+		if err != nil {
+			return err
+		}
+		for _, constraint := range constraints {
+			g.Resources = append(g.Resources, terraformutils.NewResource(
+				folder.Name+"/"+constraint,
+				strings.TrimPrefix(folder.Name, "folders/")+"_"+strings.TrimPrefix(constraint, "constraints/"),
+				"google_folder_organization_policy",
+				g.ProviderName,
+				map[string]string{
+					"folder":     folder.Name,
+					"constraint": constraint,
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+	}
+	return nil
+}
+
+// PostConvertHook references parent folders, IAM members and policies through google_folder
+func (g *FoldersGenerator) PostConvertHook() error {
+	names := map[string]string{}
+	for _, r := range g.Resources {
+		if r.InstanceInfo.Type == "google_folder" {
+			names[r.InstanceState.ID] = r.ResourceName
+		}
+	}
+	for i, r := range g.Resources {
+		key := "folder"
+		if r.InstanceInfo.Type == "google_folder" {
+			key = "parent"
+		}
+		value, ok := r.Item[key].(string)
+		if !ok {
+			continue
+		}
+		if name, exist := names[value]; exist {
+			g.Resources[i].Item[key] = "${google_folder." + name + ".name}"
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package gcp
 
 import (
+	"context"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
 )
@@ -18,6 +20,10 @@ func (s *GCPFacade) SetVerbose(verbose bool) {
 	s.service.SetVerbose(verbose)
 }
 
+func (s *GCPFacade) SetContext(ctx context.Context) {
+	s.service.SetContext(ctx)
+}
+
 func (s *GCPFacade) ParseFilters(rawFilters []string) {
 	s.service.ParseFilters(rawFilters)
 }
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"google.golang.org/api/compute/v1"
@@ -29,6 +30,8 @@ type GCPProvider struct { //nolint
 	projectName  string
 	region       compute.Region
 	providerType string
+	organization string
+	folders      []string
 }
 
 func GetRegions(project string) []string {
@@ -66,7 +69,21 @@ func getRegion(project, regionName string) *compute.Region {
 }
 
 // check projectName in env params
+// args: region, project, provider type and optional organization and comma separated folders
 func (p *GCPProvider) Init(args []string) error {
+	if len(args) > 3 {
+		p.organization = args[3]
+	}
+	if len(args) > 4 && args[4] != "" {
+		p.folders = strings.Split(args[4], ",")
+	}
+	p.providerType = args[2]
+	if p.organization != "" || len(p.folders) > 0 {
+		// hierarchy services are not bound to a project
+		p.projectName = args[1]
+		p.region = compute.Region{}
+		return nil
+	}
 	projectName := os.Getenv("GOOGLE_CLOUD_PROJECT")
 	if len(args) > 1 {
 		projectName = args[1]
@@ -76,7 +93,6 @@ func (p *GCPProvider) Init(args []string) error {
 	}
 	p.projectName = projectName
 	p.region = *getRegion(projectName, args[0])
-	p.providerType = args[2]
 	return nil
 }
 
@@ -87,6 +103,54 @@ func (p *GCPProvider) GetName() string {
 	return "google"
 }
 
+// GetDefaultTags lifts labels into default_labels, supported by the provider since 5.0
+func (p GCPProvider) GetDefaultTags() terraformutils.DefaultTags {
+	return terraformutils.DefaultTags{
+		Attribute:    "labels",
+		Dropped:      []string{"effective_labels", "terraform_labels"},
+		ProviderPath: []string{"default_labels"},
+	}
+}
+
+// GetParameters replaces the project, the region and its zones with variables
+func (p GCPProvider) GetParameters(ctx context.Context) ([]terraformutils.Parameter, error) {
+	parameters := []terraformutils.Parameter{}
+	if p.projectName != "" {
+		parameters = append(parameters, terraformutils.Parameter{
+			Value:             p.projectName,
+			Reference:         "${var.project}",
+			ProviderReference: "${var.project}",
+			Variables:         map[string]string{"project": p.projectName},
+		})
+	}
+	if p.region.Name == "" {
+		return parameters, nil
+	}
+	region := map[string]string{"region": p.region.Name}
+	parameters = append(parameters, terraformutils.Parameter{
+		Value:     p.region.Name,
+		Reference: "${var.region}",
+		Variables: region,
+	})
+	for _, zoneLink := range p.region.Zones {
+		// zones are links to compute zones, e.g. .../zones/us-central1-a
+		zone := zoneLink[strings.LastIndex(zoneLink, "/")+1:]
+		if !strings.HasPrefix(zone, p.region.Name+"-") {
+			continue
+		}
+		parameters = append(parameters, terraformutils.Parameter{
+			Value:     zone,
+			Reference: "${var.region}" + strings.TrimPrefix(zone, p.region.Name),
+			Variables: region,
+		})
+	}
+	return parameters, nil
+}
+
+func (p *GCPProvider) GetSource() string {
+	return "hashicorp/" + p.GetName()
+}
+
 func (p *GCPProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -97,8 +161,10 @@ func (p *GCPProvider) InitService(serviceName string, verbose bool) error {
 	p.Service.SetVerbose(verbose)
 	p.Service.SetProviderName(p.GetName())
 	p.Service.SetArgs(map[string]interface{}{
-		"region":  p.region,
-		"project": p.projectName,
+		"region":       p.region,
+		"project":      p.projectName,
+		"organization": p.organization,
+		"folders":      p.folders,
 	})
 	return nil
 }
@@ -112,6 +178,7 @@ func (p *GCPProvider) GetSupportedService() map[string]terraformutils.ServiceGen
 	services["cloudtasks"] = &GCPFacade{service: &CloudTaskGenerator{}}
 	services["dataProc"] = &GCPFacade{service: &DataprocGenerator{}}
 	services["dns"] = &GCPFacade{service: &CloudDNSGenerator{}}
+	services["folders"] = &GCPFacade{service: &FoldersGenerator{}}
 	services["gcs"] = &GCPFacade{service: &GcsGenerator{}}
 	services["gke"] = &GCPFacade{service: &GkeGenerator{}}
 	services["iam"] = &GCPFacade{service: &IamGenerator{}}
@@ -119,7 +186,9 @@ func (p *GCPProvider) GetSupportedService() map[string]terraformutils.ServiceGen
 	services["logging"] = &GCPFacade{service: &LoggingGenerator{}}
 	services["memoryStore"] = &GCPFacade{service: &MemoryStoreGenerator{}}
 	services["monitoring"] = &GCPFacade{service: &MonitoringGenerator{}}
+	services["organization"] = &GCPFacade{service: &OrganizationGenerator{}}
 	services["project"] = &GCPFacade{service: &ProjectGenerator{}}
+	services["projects"] = &GCPFacade{service: &ProjectsGenerator{}}
 	services["instances"] = &GCPFacade{service: &InstancesGenerator{}}
 	services["pubsub"] = &GCPFacade{service: &PubsubGenerator{}}
 	services["schedulerJobs"] = &GCPFacade{service: &SchedulerJobsGenerator{}}
@@ -141,6 +210,7 @@ func (GCPProvider) GetResourceConnections() map[string]map[string][]string {
 		},
 		"regionInstanceGroupManagers": {"instanceTemplates": []string{"version.instance_template", "self_link"}},
 		"instanceGroups":              {"instanceTemplates": []string{"version.instance_template", "self_link"}},
+		"projects":                    {"folders": []string{"folder_id", "folder_id"}},
 		"routes":                      {"networks": []string{"network", "self_link"}},
 		"subnetworks":                 {"networks": []string{"network", "self_link"}},
 		"forwardingRules": {
@@ -186,11 +256,13 @@ func (GCPProvider) GetResourceConnections() map[string]map[string][]string {
 	}
 }
 func (p GCPProvider) GetProviderData(arg ...string) map[string]interface{} {
+	config := map[string]interface{}{}
+	if p.projectName != "" {
+		config["project"] = p.projectName
+	}
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
-			p.GetName(): map[string]interface{}{
-				"project": p.projectName,
-			},
+			p.GetName(): config,
 		},
 	}
 }
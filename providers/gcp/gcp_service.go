@@ -15,11 +15,15 @@
 package gcp
 
 import (
+	"google.golang.org/api/option"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 )
 
 type GCPService struct { //nolint
 	terraformutils.Service
+	// clientOptions are added to the options of API clients, tests set an endpoint
+	clientOptions []option.ClientOption
 }
 
 func (s *GCPService) applyCustomProviderType(resources []terraformutils.Resource, providerName string) []terraformutils.Resource {
@@ -182,7 +182,7 @@ func (g *GcsGenerator) createTransferJobsResources(ctx context.Context, storageT
 // from each bucket  create 1 TerraformResource
 // Need bucket name as ID for terraform resource
 func (g *GcsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	gcsService, err := storage.NewService(ctx)
 	if err != nil {
 		log.Print(err)
@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -92,7 +91,7 @@ func (g *GkeGenerator) initNodePools(nodePools []*container.NodePool, clusterNam
 
 // Generate TerraformResources from GCP API,
 func (g *GkeGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	service, err := container.NewService(ctx)
 	if err != nil {
 		log.Print(err)
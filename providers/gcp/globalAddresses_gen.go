@@ -62,7 +62,7 @@ func (g GlobalAddressesGenerator) createResources(ctx context.Context, globalAdd
 // from each globalAddresses create 1 TerraformResource
 // Need globalAddresses name as ID for terraform resource
 func (g *GlobalAddressesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -61,7 +61,7 @@ func (g GlobalForwardingRulesGenerator) createResources(ctx context.Context, glo
 // from each globalForwardingRules create 1 TerraformResource
 // Need globalForwardingRules name as ID for terraform resource
 func (g *GlobalForwardingRulesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g HealthChecksGenerator) createResources(ctx context.Context, healthChecks
 // from each healthChecks create 1 TerraformResource
 // Need healthChecks name as ID for terraform resource
 func (g *HealthChecksGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
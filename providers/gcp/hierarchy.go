@@ -0,0 +1,165 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// HierarchyServices are imported once per organization or folder tree, not per project
+var HierarchyServices = []string{"organization", "folders", "projects"}
+
+var errNoHierarchy = errors.New("gcp: --organization or --folders must be set")
+
+// hierarchyRoots returns the resource names the walk starts from
+func hierarchyRoots(organization string, folders []string) []string {
+	roots := []string{}
+	if organization != "" {
+		roots = append(roots, "organizations/"+strings.TrimPrefix(organization, "organizations/"))
+	}
+	for _, folder := range folders {
+		roots = append(roots, "folders/"+strings.TrimPrefix(folder, "folders/"))
+	}
+	return roots
+}
+
+// listFolders walks the folder tree below parents, parents that are folders are included
+func listFolders(ctx context.Context, crm *crmv3.Service, parents []string) ([]*crmv3.Folder, error) {
+	folders := []*crmv3.Folder{}
+	for _, parent := range parents {
+		if strings.HasPrefix(parent, "folders/") {
+			folder, err := crm.Folders.Get(parent).Context(ctx).Do()
+			if err != nil {
+				return nil, err
+			}
+			folders = append(folders, folder)
+		}
+	}
+	queue := append([]string{}, parents...)
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		err := crm.Folders.List().Parent(parent).Pages(ctx, func(page *crmv3.ListFoldersResponse) error {
+			for _, folder := range page.Folders {
+				folders = append(folders, folder)
+				queue = append(queue, folder.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return folders, nil
+}
+
+// listHierarchyProjects returns active projects directly below parents or any of their folders
+func listHierarchyProjects(ctx context.Context, crm *crmv3.Service, parents []string) ([]*crmv3.Project, error) {
+	folders, err := listFolders(ctx, crm, parents)
+	if err != nil {
+		return nil, err
+	}
+	containers := []string{}
+	for _, parent := range parents {
+		if strings.HasPrefix(parent, "organizations/") {
+			containers = append(containers, parent)
+		}
+	}
+	for _, folder := range folders {
+		containers = append(containers, folder.Name)
+	}
+	projects := []*crmv3.Project{}
+	for _, container := range containers {
+		err := crm.Projects.List().Parent(container).Pages(ctx, func(page *crmv3.ListProjectsResponse) error {
+			for _, project := range page.Projects {
+				if project.State == "ACTIVE" {
+					projects = append(projects, project)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return projects, nil
+}
+
+// ListProjects returns ids of all active projects in organization and folders trees
+func ListProjects(ctx context.Context, organization string, folders []string) ([]string, error) {
+	crm, err := crmv3.NewService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := listHierarchyProjects(ctx, crm, hierarchyRoots(organization, folders))
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, project := range projects {
+		ids = append(ids, project.ProjectId)
+	}
+	return ids, nil
+}
+
+// createHierarchyIamMembers emits one resourceType per role and member of policy
+func createHierarchyIamMembers(policy *crmv3.Policy, resourceType, providerName, parentKey, parentValue string) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	for _, b := range policy.Bindings {
+		if b.Condition != nil {
+			// conditional bindings have no stable import id
+			continue
+		}
+		for _, m := range b.Members {
+			resources = append(resources, terraformutils.NewResource(
+				parentValue+" "+b.Role+" "+m,
+				parentValue+"_"+b.Role+m,
+				resourceType,
+				providerName,
+				map[string]string{
+					parentKey: parentValue,
+					"role":    b.Role,
+					"member":  m,
+				},
+				IamAllowEmptyValues,
+				IamAdditionalFields,
+			))
+		}
+	}
+	return resources
+}
+
+type orgPoliciesCall interface {
+	Pages(ctx context.Context, f func(*cloudresourcemanager.ListOrgPoliciesResponse) error) error
+}
+
+// listOrgPolicies returns the constraints explicitly set on a resource
+func listOrgPolicies(ctx context.Context, call orgPoliciesCall) ([]string, error) {
+	constraints := []string{}
+	err := call.Pages(ctx, func(page *cloudresourcemanager.ListOrgPoliciesResponse) error {
+		for _, policy := range page.Policies {
+			constraints = append(constraints, policy.Constraint)
+		}
+		return nil
+	})
+	return constraints, err
+}
@@ -0,0 +1,191 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/option"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// hierarchyResponses describe organization 123 with active folders 1 and 1/3, a folder being
+// deleted and projects at the organization and in folder 3
+var hierarchyResponses = map[string]string{
+	"GET /v3/folders?parent=organizations/123": `{"folders":[
+		{"name":"folders/1","parent":"organizations/123","displayName":"eng","state":"ACTIVE"},
+		{"name":"folders/2","parent":"organizations/123","displayName":"old","state":"DELETE_REQUESTED"}]}`,
+	"GET /v3/folders?parent=folders/1": `{"folders":[
+		{"name":"folders/3","parent":"folders/1","displayName":"platform","state":"ACTIVE"}]}`,
+	"GET /v3/folders/1": `{"name":"folders/1","parent":"organizations/123","displayName":"eng","state":"ACTIVE"}`,
+	"GET /v3/projects?parent=organizations/123": `{"projects":[
+		{"projectId":"p-root","parent":"organizations/123","state":"ACTIVE"}]}`,
+	"GET /v3/projects?parent=folders/3": `{"projects":[
+		{"projectId":"p-app","parent":"folders/3","state":"ACTIVE"},
+		{"projectId":"p-gone","parent":"folders/3","state":"DELETE_REQUESTED"}]}`,
+	"POST /v3/folders/1:getIamPolicy": `{"bindings":[
+		{"role":"roles/viewer","members":["group:eng@example.com"]},
+		{"role":"roles/editor","members":["group:oncall@example.com"],"condition":{"expression":"request.time < timestamp('2030-01-01T00:00:00Z')"}}]}`,
+	"POST /v1/folders/1:listOrgPolicies": `{"policies":[{"constraint":"constraints/compute.vmExternalIpAccess"}]}`,
+	"POST /v3/organizations/123:getIamPolicy": `{"bindings":[
+		{"role":"roles/owner","members":["user:admin@example.com"]}]}`,
+	"POST /v1/organizations/123:listOrgPolicies": `{"policies":[{"constraint":"constraints/iam.disableServiceAccountKeyCreation"}]}`,
+	"GET /v1/projects/p-root/billingInfo":        `{"billingAccountName":"billingAccounts/0000-AAAA"}`,
+}
+
+// newFakeResourceManager serves hierarchyResponses, other calls get an empty response, and
+// returns the options of clients using it
+func newFakeResourceManager(t *testing.T) []option.ClientOption {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		if parent := r.URL.Query().Get("parent"); parent != "" {
+			key += "?parent=" + parent
+		}
+		body, exist := hierarchyResponses[key]
+		if !exist {
+			body = "{}"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return []option.ClientOption{option.WithEndpoint(server.URL + "/"), option.WithoutAuthentication()}
+}
+
+func initHierarchyService(t *testing.T, generator terraformutils.ServiceGenerator, service *GCPService, organization string, folders []string) []terraformutils.Resource {
+	service.clientOptions = newFakeResourceManager(t)
+	generator.SetProviderName("google")
+	generator.SetArgs(map[string]interface{}{"organization": organization, "folders": folders})
+	if err := generator.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	return generator.GetResources()
+}
+
+func resourceIDs(resources []terraformutils.Resource) []string {
+	ids := []string{}
+	for _, r := range resources {
+		ids = append(ids, r.InstanceInfo.Type+" "+r.InstanceState.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestFoldersGenerator(t *testing.T) {
+	g := &FoldersGenerator{}
+	resources := initHierarchyService(t, g, &g.GCPService, "123", []string{})
+	expected := []string{
+		"google_folder folders/1",
+		"google_folder folders/3",
+		"google_folder_iam_member folders/1 roles/viewer group:eng@example.com",
+		"google_folder_organization_policy folders/1/constraints/compute.vmExternalIpAccess",
+	}
+	if ids := resourceIDs(resources); !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, want %v", ids, expected)
+	}
+
+	for i := range g.Resources {
+		g.Resources[i].Item = map[string]interface{}{}
+		for _, key := range []string{"parent", "folder"} {
+			if value, exist := g.Resources[i].InstanceState.Attributes[key]; exist {
+				g.Resources[i].Item[key] = value
+			}
+		}
+	}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range g.Resources {
+		if r.InstanceState.ID == "folders/3" && r.Item["parent"] != "${google_folder.tfer--1_eng.name}" {
+			t.Errorf("parent of folders/3 isn't referenced: %v", r.Item["parent"])
+		}
+		if r.InstanceInfo.Type == "google_folder_iam_member" && r.Item["folder"] != "${google_folder.tfer--1_eng.name}" {
+			t.Errorf("folder of %s isn't referenced: %v", r.InstanceState.ID, r.Item["folder"])
+		}
+	}
+}
+
+func TestOrganizationGenerator(t *testing.T) {
+	g := &OrganizationGenerator{}
+	resources := initHierarchyService(t, g, &g.GCPService, "organizations/123", []string{})
+	expected := []string{
+		"google_organization_iam_member 123 roles/owner user:admin@example.com",
+		"google_organization_policy 123/constraints/iam.disableServiceAccountKeyCreation",
+	}
+	if ids := resourceIDs(resources); !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, want %v", ids, expected)
+	}
+}
+
+func TestProjectsGenerator(t *testing.T) {
+	g := &ProjectsGenerator{}
+	resources := initHierarchyService(t, g, &g.GCPService, "123", []string{})
+	attributes := map[string]map[string]string{}
+	for _, r := range resources {
+		attributes[r.InstanceState.ID] = r.InstanceState.Attributes
+	}
+	expected := map[string]map[string]string{
+		"p-root": {"project_id": "p-root", "auto_create_network": "true", "org_id": "123", "billing_account": "0000-AAAA"},
+		"p-app":  {"project_id": "p-app", "auto_create_network": "true", "folder_id": "3"},
+	}
+	for id, expectedAttributes := range expected {
+		for key, value := range expectedAttributes {
+			if attributes[id][key] != value {
+				t.Errorf("project %s: %s = %q, want %q", id, key, attributes[id][key], value)
+			}
+		}
+	}
+	if len(attributes) != len(expected) {
+		t.Errorf("unexpected projects %v", resourceIDs(resources))
+	}
+}
+
+func TestListHierarchyProjects(t *testing.T) {
+	ctx := context.Background()
+	crm, err := crmv3.NewService(ctx, newFakeResourceManager(t)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		organization string
+		folders      []string
+		projects     []string
+	}{
+		"organization": {organization: "123", projects: []string{"p-app", "p-root"}},
+		"folder":       {folders: []string{"folders/1"}, projects: []string{"p-app"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			projects, err := listHierarchyProjects(ctx, crm, hierarchyRoots(tc.organization, tc.folders))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []string{}
+			for _, project := range projects {
+				ids = append(ids, project.ProjectId)
+			}
+			sort.Strings(ids)
+			if !reflect.DeepEqual(ids, tc.projects) {
+				t.Errorf("got %v, want %v", ids, tc.projects)
+			}
+		})
+	}
+}
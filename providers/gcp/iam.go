@@ -15,7 +15,6 @@
 package gcp
 
 import (
-	"context"
 	"log"
 	"regexp"
 
@@ -112,7 +111,7 @@ func (g *IamGenerator) createIamMemberResources(policy *cloudresourcemanager.Pol
 }
 
 func (g *IamGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 
 	projectID := g.GetArgs()["project"].(string)
 	client, err := admin.NewIamClient(ctx)
@@ -125,12 +124,12 @@ func (g *IamGenerator) InitResources() error {
 		return err
 	}
 
-	cm, err := cloudresourcemanager.NewService(context.Background())
+	cm, err := cloudresourcemanager.NewService(g.Context())
 	if err != nil {
 		return err
 	}
 	rb := &cloudresourcemanager.GetIamPolicyRequest{}
-	policyResponse, err := cm.Projects.GetIamPolicy(projectID, rb).Context(context.Background()).Do()
+	policyResponse, err := cm.Projects.GetIamPolicy(projectID, rb).Context(g.Context()).Do()
 	if err != nil {
 		return err
 	}
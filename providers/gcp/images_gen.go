@@ -62,7 +62,7 @@ func (g ImagesGenerator) createResources(ctx context.Context, imagesList *comput
 // from each images create 1 TerraformResource
 // Need images name as ID for terraform resource
 func (g *ImagesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
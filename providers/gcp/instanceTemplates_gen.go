@@ -62,7 +62,7 @@ func (g InstanceTemplatesGenerator) createResources(ctx context.Context, instanc
 // from each instanceTemplates create 1 TerraformResource
 // Need instanceTemplates name as ID for terraform resource
 func (g *InstanceTemplatesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
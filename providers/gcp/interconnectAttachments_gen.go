@@ -62,7 +62,7 @@ func (g InterconnectAttachmentsGenerator) createResources(ctx context.Context, i
 // from each interconnectAttachments create 1 TerraformResource
 // Need interconnectAttachments name as ID for terraform resource
 func (g *InterconnectAttachmentsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -88,7 +88,7 @@ func (g *KmsGenerator) createKmsKeyResources(ctx context.Context, keyRingName st
 
 // Generate TerraformResources from GCP API,
 func (g *KmsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	kmsService, err := cloudkms.NewService(ctx)
 	if err != nil {
 		return err
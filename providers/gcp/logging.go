@@ -62,7 +62,7 @@ func (g *LoggingGenerator) loadLoggingMetrics(ctx context.Context, client *logad
 // Generate TerraformResources from GCP API
 func (g *LoggingGenerator) InitResources() error {
 	project := g.GetArgs()["project"].(string)
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := logadmin.NewClient(ctx, project)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g NetworksGenerator) createResources(ctx context.Context, networksList *co
 // from each networks create 1 TerraformResource
 // Need networks name as ID for terraform resource
 func (g *NetworksGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
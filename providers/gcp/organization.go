@@ -0,0 +1,72 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+type OrganizationGenerator struct {
+	GCPService
+}
+
+// Generate org level IAM members and organization policies
+func (g *OrganizationGenerator) InitResources() error {
+	organization := strings.TrimPrefix(g.GetArgs()["organization"].(string), "organizations/")
+	if organization == "" {
+		return errors.New("gcp: organization service requires --organization")
+	}
+	ctx := g.Context()
+
+	crm, err := crmv3.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	policy, err := crm.Organizations.GetIamPolicy("organizations/"+organization, &crmv3.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	g.Resources = createHierarchyIamMembers(policy, "google_organization_iam_member", g.ProviderName, "org_id", organization)
+
+	crmv1, err := cloudresourcemanager.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	constraints, err := listOrgPolicies(ctx, crmv1.Organizations.ListOrgPolicies("organizations/"+organization, &cloudresourcemanager.ListOrgPoliciesRequest{}))
+	if err != nil {
+		return err
+	}
+	for _, constraint := range constraints {
+		g.Resources = append(g.Resources, terraformutils.NewResource(
+			organization+"/"+constraint,
+			organization+"_"+strings.TrimPrefix(constraint, "constraints/"),
+			"google_organization_policy",
+			g.ProviderName,
+			map[string]string{
+				"org_id":     organization,
+				"constraint": constraint,
+			},
+			[]string{},
+			map[string]interface{}{},
+		))
+	}
+	return nil
+}
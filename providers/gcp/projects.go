@@ -0,0 +1,78 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"log"
+	"strings"
+
+	"google.golang.org/api/cloudbilling/v1"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+type ProjectsGenerator struct {
+	GCPService
+}
+
+// Generate google_project for every project of the hierarchy with its folder and billing account
+func (g *ProjectsGenerator) InitResources() error {
+	roots := hierarchyRoots(g.GetArgs()["organization"].(string), g.GetArgs()["folders"].([]string))
+	if len(roots) == 0 {
+		return errNoHierarchy
+	}
+	ctx := g.Context()
+
+	crm, err := crmv3.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	billing, err := cloudbilling.NewService(ctx, g.clientOptions...)
+	if err != nil {
+		return err
+	}
+	projects, err := listHierarchyProjects(ctx, crm, roots)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		attributes := map[string]string{
+			"project_id":          project.ProjectId,
+			"auto_create_network": "true",
+		}
+		if strings.HasPrefix(project.Parent, "folders/") {
+			attributes["folder_id"] = strings.TrimPrefix(project.Parent, "folders/")
+		} else {
+			attributes["org_id"] = strings.TrimPrefix(project.Parent, "organizations/")
+		}
+		info, err := billing.Projects.GetBillingInfo("projects/" + project.ProjectId).Context(ctx).Do()
+		if err != nil {
+			log.Println(err)
+		} else if info.BillingAccountName != "" {
+			attributes["billing_account"] = strings.TrimPrefix(info.BillingAccountName, "billingAccounts/")
+		}
+		g.Resources = append(g.Resources, terraformutils.NewResource(
+			project.ProjectId,
+			project.ProjectId,
+			"google_project",
+			g.ProviderName,
+			attributes,
+			projectAllowEmptyValues,
+			projectAdditionalFields,
+		))
+	}
+	return nil
+}
@@ -88,7 +88,7 @@ func (g PubsubGenerator) createTopicsListResources(ctx context.Context, topicsLi
 
 // Generate TerraformResources from GCP API,
 func (g *PubsubGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	pubsubService, err := pubsub.NewService(ctx)
 	if err != nil {
 		return err
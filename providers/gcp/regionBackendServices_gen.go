@@ -62,7 +62,7 @@ func (g RegionBackendServicesGenerator) createResources(ctx context.Context, reg
 // from each regionBackendServices create 1 TerraformResource
 // Need regionBackendServices name as ID for terraform resource
 func (g *RegionBackendServicesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g RegionInstanceGroupsGenerator) createResources(ctx context.Context, regi
 // from each regionInstanceGroups create 1 TerraformResource
 // Need regionInstanceGroups name as ID for terraform resource
 func (g *RegionInstanceGroupsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
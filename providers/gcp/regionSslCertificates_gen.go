@@ -62,7 +62,7 @@ func (g RegionSslCertificatesGenerator) createResources(ctx context.Context, reg
 // from each regionSslCertificates create 1 TerraformResource
 // Need regionSslCertificates name as ID for terraform resource
 func (g *RegionSslCertificatesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
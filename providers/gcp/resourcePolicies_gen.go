@@ -62,7 +62,7 @@ func (g ResourcePoliciesGenerator) createResources(ctx context.Context, resource
 // from each resourcePolicies create 1 TerraformResource
 // Need resourcePolicies name as ID for terraform resource
 func (g *ResourcePoliciesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
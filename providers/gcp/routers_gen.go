@@ -62,7 +62,7 @@ func (g RoutersGenerator) createResources(ctx context.Context, routersList *comp
 // from each routers create 1 TerraformResource
 // Need routers name as ID for terraform resource
 func (g *RoutersGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -63,7 +63,7 @@ func (g SchedulerJobsGenerator) createResources(ctx context.Context, jobsList *c
 
 // Generate TerraformResources from GCP API,
 func (g *SchedulerJobsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	cloudSchedulerService, err := cloudscheduler.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g SecurityPoliciesGenerator) createResources(ctx context.Context, security
 // from each securityPolicies create 1 TerraformResource
 // Need securityPolicies name as ID for terraform resource
 func (g *SecurityPoliciesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
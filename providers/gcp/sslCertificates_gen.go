@@ -62,7 +62,7 @@ func (g SslCertificatesGenerator) createResources(ctx context.Context, sslCertif
 // from each sslCertificates create 1 TerraformResource
 // Need sslCertificates name as ID for terraform resource
 func (g *SslCertificatesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g SslPoliciesGenerator) createResources(ctx context.Context, sslPoliciesLi
 // from each sslPolicies create 1 TerraformResource
 // Need sslPolicies name as ID for terraform resource
 func (g *SslPoliciesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
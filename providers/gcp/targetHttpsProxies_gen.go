@@ -62,7 +62,7 @@ func (g TargetHttpsProxiesGenerator) createResources(ctx context.Context, target
 // from each targetHttpsProxies create 1 TerraformResource
 // Need targetHttpsProxies name as ID for terraform resource
 func (g *TargetHttpsProxiesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
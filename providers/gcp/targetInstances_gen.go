@@ -64,7 +64,7 @@ func (g TargetInstancesGenerator) createResources(ctx context.Context, targetIns
 // from each targetInstances create 1 TerraformResource
 // Need targetInstances name as ID for terraform resource
 func (g *TargetInstancesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func (g TargetSslProxiesGenerator) createResources(ctx context.Context, targetSs
 // from each targetSslProxies create 1 TerraformResource
 // Need targetSslProxies name as ID for terraform resource
 func (g *TargetSslProxiesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	computeService, err := compute.NewService(ctx)
 	if err != nil {
 		return err
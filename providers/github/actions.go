@@ -0,0 +1,204 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+
+	githubAPI "github.com/google/go-github/v35/github"
+)
+
+type ActionsGenerator struct {
+	GithubService
+}
+
+type actionsVariable struct {
+	Name string `json:"name"`
+}
+
+type actionsVariables struct {
+	Variables []actionsVariable `json:"variables"`
+}
+
+// InitResources generates TerraformResources from Github API,
+func (g *ActionsGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+	owner := g.Args["owner"].(string)
+
+	g.Resources = append(g.Resources, g.createOrganizationSecretResources(ctx, client)...)
+	g.Resources = append(g.Resources, g.createVariableResources(ctx, client, fmt.Sprintf("orgs/%s/actions/variables", owner), nil)...)
+	g.Resources = append(g.Resources, g.createRunnerGroupResources(ctx, client)...)
+
+	repos, err := g.listRepositories(ctx, client)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, repo := range repos {
+		g.Resources = append(g.Resources, g.createRepositorySecretResources(ctx, client, repo)...)
+		g.Resources = append(g.Resources, g.createVariableResources(ctx, client, fmt.Sprintf("repos/%s/%s/actions/variables", owner, repo.GetName()), repo)...)
+	}
+	return nil
+}
+
+func (g *ActionsGenerator) createOrganizationSecretResources(ctx context.Context, client *githubAPI.Client) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &githubAPI.ListOptions{PerPage: 100}
+	for {
+		secrets, resp, err := client.Actions.ListOrgSecrets(ctx, g.Args["owner"].(string), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, secret := range secrets.Secrets {
+			resources = append(resources, terraformutils.NewSimpleResource(
+				secret.Name,
+				secret.Name,
+				"github_actions_organization_secret",
+				"github",
+				[]string{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+func (g *ActionsGenerator) createRepositorySecretResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &githubAPI.ListOptions{PerPage: 100}
+	for {
+		secrets, resp, err := client.Actions.ListRepoSecrets(ctx, g.Args["owner"].(string), repo.GetName(), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, secret := range secrets.Secrets {
+			resources = append(resources, terraformutils.NewResource(
+				repo.GetName()+":"+secret.Name,
+				repo.GetName()+"_"+secret.Name,
+				"github_actions_secret",
+				"github",
+				map[string]string{
+					"repository":  repo.GetName(),
+					"secret_name": secret.Name,
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+// createVariableResources creates organization variables when repo is nil, repository variables otherwise
+func (g *ActionsGenerator) createVariableResources(ctx context.Context, client *githubAPI.Client, u string, repo *githubAPI.Repository) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	page := 1
+	for {
+		variables := actionsVariables{}
+		resp, err := getPage(ctx, client, u, page, &variables)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, variable := range variables.Variables {
+			if repo == nil {
+				resources = append(resources, terraformutils.NewSimpleResource(
+					variable.Name,
+					variable.Name,
+					"github_actions_organization_variable",
+					"github",
+					[]string{},
+				))
+				continue
+			}
+			resources = append(resources, terraformutils.NewResource(
+				repo.GetName()+":"+variable.Name,
+				repo.GetName()+"_"+variable.Name,
+				"github_actions_variable",
+				"github",
+				map[string]string{
+					"repository":    repo.GetName(),
+					"variable_name": variable.Name,
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return resources
+}
+
+func (g *ActionsGenerator) createRunnerGroupResources(ctx context.Context, client *githubAPI.Client) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &githubAPI.ListOptions{PerPage: 100}
+	for {
+		groups, resp, err := client.Actions.ListOrganizationRunnerGroups(ctx, g.Args["owner"].(string), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, group := range groups.RunnerGroups {
+			if group.GetDefault() {
+				continue
+			}
+			resources = append(resources, terraformutils.NewSimpleResource(
+				strconv.FormatInt(group.GetID(), 10),
+				group.GetName(),
+				"github_actions_runner_group",
+				"github",
+				[]string{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+// PostConvertHook replaces secret values with variables
+func (g *ActionsGenerator) PostConvertHook() error {
+	for i := range g.Resources {
+		switch g.Resources[i].InstanceInfo.Type {
+		case "github_actions_secret", "github_actions_organization_secret":
+			delete(g.Resources[i].Item, "encrypted_value")
+			secretVariable(&g.Resources[i], "plaintext_value")
+		}
+	}
+	return nil
+}
@@ -113,6 +113,10 @@ func (p *GithubProvider) GetName() string {
 	return "github"
 }
 
+func (p *GithubProvider) GetSource() string {
+	return "integrations/github"
+}
+
 func (p *GithubProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -136,13 +140,16 @@ func (p *GithubProvider) InitService(serviceName string, verbose bool) error {
 // GetSupportedService return map of support service for Github
 func (p *GithubProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
 	return map[string]terraformutils.ServiceGenerator{
-		"members":               &MembersGenerator{},
-		"organization":          &OrganizationGenerator{},
-		"organization_blocks":   &OrganizationBlockGenerator{},
-		"organization_projects": &OrganizationProjectGenerator{},
-		"organization_webhooks": &OrganizationWebhooksGenerator{},
-		"repositories":          &RepositoriesGenerator{},
-		"teams":                 &TeamsGenerator{},
-		"user_ssh_keys":         &UserSSHKeyGenerator{},
+		"actions":                 &ActionsGenerator{},
+		"members":                 &MembersGenerator{},
+		"organization":            &OrganizationGenerator{},
+		"organization_blocks":     &OrganizationBlockGenerator{},
+		"organization_projects":   &OrganizationProjectGenerator{},
+		"organization_webhooks":   &OrganizationWebhooksGenerator{},
+		"repositories":            &RepositoriesGenerator{},
+		"repository_environments": &RepositoryEnvironmentsGenerator{},
+		"rulesets":                &RulesetsGenerator{},
+		"teams":                   &TeamsGenerator{},
+		"user_ssh_keys":           &UserSSHKeyGenerator{},
 	}
 }
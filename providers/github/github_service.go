@@ -16,7 +16,9 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/bradleyfalzon/ghinstallation/v2"
@@ -38,7 +40,7 @@ func (g *GithubService) createClient() (*github.Client, error) {
 }
 
 func (g *GithubService) createRegularClient() *github.Client {
-	ctx := context.Background()
+	ctx := g.Context()
 	if g.Args["app_id"].(int64) != 0 && g.Args["installation_id"].(int64) != 0 && g.Args["pem"].(string) != "" {
 		itr, err := ghinstallation.New(http.DefaultTransport, g.Args["app_id"].(int64), g.Args["installation_id"].(int64), []byte(g.Args["pem"].(string)))
 		if err != nil {
@@ -54,7 +56,7 @@ func (g *GithubService) createRegularClient() *github.Client {
 }
 
 func (g *GithubService) createEnterpriseClient() (*github.Client, error) {
-	ctx := context.Background()
+	ctx := g.Context()
 	baseURL := g.GetArgs()["base_url"].(string)
 	if g.Args["app_id"].(int64) != 0 && g.Args["installation_id"].(int64) != 0 && g.Args["pem"].(string) != "" {
 		itr, err := ghinstallation.New(http.DefaultTransport, g.Args["app_id"].(int64), g.Args["installation_id"].(int64), []byte(g.Args["pem"].(string)))
@@ -69,3 +71,50 @@ func (g *GithubService) createEnterpriseClient() (*github.Client, error) {
 	tc := oauth2.NewClient(ctx, ts)
 	return github.NewEnterpriseClient(baseURL, baseURL, tc)
 }
+
+// listRepositories returns all repositories of the owner organization
+func (g *GithubService) listRepositories(ctx context.Context, client *github.Client) ([]*github.Repository, error) {
+	var repositories []*github.Repository
+	opt := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, g.GetArgs()["owner"].(string), opt)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, repos...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return repositories, nil
+}
+
+// getPage reads one page of a REST API endpoint that isn't covered by the go-github client into v
+func getPage(ctx context.Context, client *github.Client, u string, page int, v interface{}) (*github.Response, error) {
+	separator := "?"
+	if strings.Contains(u, "?") {
+		separator = "&"
+	}
+	req, err := client.NewRequest("GET", fmt.Sprintf("%s%sper_page=100&page=%d", u, separator, page), nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, v)
+}
+
+// secretVariable replaces the write-only value of a secret with a sensitive variable,
+// GitHub API never returns secret values
+func secretVariable(resource *terraformutils.Resource, key string) {
+	variableName := resource.ResourceName + "_" + key
+	resource.Item[key] = "${var." + variableName + "}"
+	if resource.Variables == nil {
+		resource.Variables = map[string]map[string]interface{}{}
+	}
+	resource.Variables[variableName] = map[string]interface{}{
+		"description": "Value of secret " + resource.InstanceState.ID,
+		"sensitive":   true,
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/v35/github"
+)
+
+// newPagedClient serves JSON pages keyed by path and page number, every page but the
+// last links to the next one
+func newPagedClient(t *testing.T, pages map[string]string) *github.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			page = 1
+		}
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("%s isn't read 100 items per page", r.URL)
+		}
+		body, exist := pages[fmt.Sprintf("%s?page=%d", r.URL.Path, page)]
+		if !exist {
+			http.NotFound(w, r)
+			return
+		}
+		if _, exist := pages[fmt.Sprintf("%s?page=%d", r.URL.Path, page+1)]; exist {
+			next := r.URL.Query()
+			next.Set("page", strconv.Itoa(page+1))
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?%s>; rel="next"`, r.Host, r.URL.Path, next.Encode()))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+func TestListRepositoriesPaging(t *testing.T) {
+	client := newPagedClient(t, map[string]string{
+		"/orgs/acme/repos?page=1": `[{"name":"infra"}]`,
+		"/orgs/acme/repos?page=2": `[{"name":"web"}]`,
+	})
+	g := &GithubService{}
+	g.SetArgs(map[string]interface{}{"owner": "acme"})
+	repos, err := g.listRepositories(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.GetName())
+	}
+	if expected := []string{"infra", "web"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, want %v", names, expected)
+	}
+}
+
+func TestGetPagePaging(t *testing.T) {
+	client := newPagedClient(t, map[string]string{
+		"/repos/acme/infra/rulesets?page=1": `[{"id":1,"name":"a"}]`,
+		"/repos/acme/infra/rulesets?page=2": `[{"id":2,"name":"b"}]`,
+	})
+	var names []string
+	for page := 1; page != 0; {
+		var rulesets []ruleset
+		// the query of the endpoint is kept along the paging parameters
+		resp, err := getPage(context.Background(), client, "repos/acme/infra/rulesets?includes_parents=false", page, &rulesets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if includesParents := resp.Request.URL.Query().Get("includes_parents"); includesParents != "false" {
+			t.Errorf("includes_parents = %q in %s", includesParents, resp.Request.URL)
+		}
+		for _, r := range rulesets {
+			names = append(names, r.Name)
+		}
+		page = resp.NextPage
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, want %v", names, expected)
+	}
+}
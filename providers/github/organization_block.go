@@ -29,7 +29,7 @@ type OrganizationBlockGenerator struct {
 
 // Generate TerraformResources from Github API,
 func (g *OrganizationBlockGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := g.createClient()
 	if err != nil {
 		return err
@@ -30,7 +30,7 @@ type OrganizationProjectGenerator struct {
 
 // Generate TerraformResources from Github API,
 func (g *OrganizationProjectGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := g.createClient()
 	if err != nil {
 		return err
@@ -29,7 +29,7 @@ type RepositoriesGenerator struct {
 
 // Generate TerraformResources from github API,
 func (g *RepositoriesGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := g.createClient()
 	if err != nil {
 		return err
@@ -72,60 +72,88 @@ func (g *RepositoriesGenerator) InitResources() error {
 
 func (g *RepositoriesGenerator) createRepositoryWebhookResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
-	hooks, _, err := client.Repositories.ListHooks(ctx, g.GetArgs()["owner"].(string), repo.GetName(), nil)
-	if err != nil {
-		log.Println(err)
-	}
-	for _, hook := range hooks {
-		resources = append(resources, terraformutils.NewResource(
-			strconv.FormatInt(hook.GetID(), 10),
-			repo.GetName()+"_"+strconv.FormatInt(hook.GetID(), 10),
-			"github_repository_webhook",
-			"github",
-			map[string]string{
-				"repository": repo.GetName(),
-			},
-			[]string{},
-			map[string]interface{}{},
-		))
+	opt := &githubAPI.ListOptions{PerPage: 100}
+	for {
+		hooks, resp, err := client.Repositories.ListHooks(ctx, g.GetArgs()["owner"].(string), repo.GetName(), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, hook := range hooks {
+			resources = append(resources, terraformutils.NewResource(
+				strconv.FormatInt(hook.GetID(), 10),
+				repo.GetName()+"_"+strconv.FormatInt(hook.GetID(), 10),
+				"github_repository_webhook",
+				"github",
+				map[string]string{
+					"repository": repo.GetName(),
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
 	return resources
 }
 
 func (g *RepositoriesGenerator) createRepositoryBranchProtectionResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
-	branches, _, err := client.Repositories.ListBranches(ctx, g.GetArgs()["owner"].(string), repo.GetName(), nil)
-	if err != nil {
-		log.Println(err)
+	opt := &githubAPI.BranchListOptions{
+		ListOptions: githubAPI.ListOptions{PerPage: 100},
 	}
-	for _, branch := range branches {
-		if branch.GetProtected() {
-			resources = append(resources, terraformutils.NewSimpleResource(
-				repo.GetName()+":"+branch.GetName(),
-				repo.GetName()+"_"+branch.GetName(),
-				"github_branch_protection",
-				"github",
-				[]string{},
-			))
+	for {
+		branches, resp, err := client.Repositories.ListBranches(ctx, g.GetArgs()["owner"].(string), repo.GetName(), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, branch := range branches {
+			if branch.GetProtected() {
+				resources = append(resources, terraformutils.NewSimpleResource(
+					repo.GetName()+":"+branch.GetName(),
+					repo.GetName()+"_"+branch.GetName(),
+					"github_branch_protection",
+					"github",
+					[]string{},
+				))
+			}
 		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
 	return resources
 }
 
 func (g *RepositoriesGenerator) createRepositoryCollaboratorResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository) []terraformutils.Resource {
 	resources := []terraformutils.Resource{}
-	collaborators, _, err := client.Repositories.ListCollaborators(ctx, g.GetArgs()["owner"].(string), repo.GetName(), nil)
-	if err != nil {
-		log.Println(err)
+	opt := &githubAPI.ListCollaboratorsOptions{
+		ListOptions: githubAPI.ListOptions{PerPage: 100},
 	}
-	for _, collaborator := range collaborators {
-		resources = append(resources, terraformutils.NewSimpleResource(
-			repo.GetName()+":"+collaborator.GetLogin(),
-			repo.GetName()+":"+collaborator.GetLogin(),
-			"github_repository_collaborator",
-			"github",
-			[]string{},
-		))
+	for {
+		collaborators, resp, err := client.Repositories.ListCollaborators(ctx, g.GetArgs()["owner"].(string), repo.GetName(), opt)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, collaborator := range collaborators {
+			resources = append(resources, terraformutils.NewSimpleResource(
+				repo.GetName()+":"+collaborator.GetLogin(),
+				repo.GetName()+":"+collaborator.GetLogin(),
+				"github_repository_collaborator",
+				"github",
+				[]string{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
 	}
 	return resources
 }
@@ -0,0 +1,154 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+
+	githubAPI "github.com/google/go-github/v35/github"
+)
+
+type RepositoryEnvironmentsGenerator struct {
+	GithubService
+}
+
+type environment struct {
+	Name string `json:"name"`
+}
+
+type environments struct {
+	Environments []environment `json:"environments"`
+}
+
+type branchPolicy struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type branchPolicies struct {
+	BranchPolicies []branchPolicy `json:"branch_policies"`
+}
+
+// InitResources generates TerraformResources from Github API,
+func (g *RepositoryEnvironmentsGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	repos, err := g.listRepositories(ctx, client)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, repo := range repos {
+		g.Resources = append(g.Resources, g.createEnvironmentResources(ctx, client, repo)...)
+	}
+	return nil
+}
+
+func (g *RepositoryEnvironmentsGenerator) createEnvironmentResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	u := fmt.Sprintf("repos/%s/%s/environments", g.Args["owner"].(string), repo.GetName())
+	page := 1
+	for {
+		envs := environments{}
+		resp, err := getPage(ctx, client, u, page, &envs)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, env := range envs.Environments {
+			resources = append(resources, terraformutils.NewResource(
+				repo.GetName()+":"+env.Name,
+				repo.GetName()+"_"+env.Name,
+				"github_repository_environment",
+				"github",
+				map[string]string{
+					"repository":  repo.GetName(),
+					"environment": env.Name,
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+			resources = append(resources, g.createDeploymentPolicyResources(ctx, client, repo, env)...)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return resources
+}
+
+func (g *RepositoryEnvironmentsGenerator) createDeploymentPolicyResources(ctx context.Context, client *githubAPI.Client, repo *githubAPI.Repository, env environment) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	u := fmt.Sprintf("repos/%s/%s/environments/%s/deployment-branch-policies", g.Args["owner"].(string), repo.GetName(), url.PathEscape(env.Name))
+	page := 1
+	for {
+		policies := branchPolicies{}
+		resp, err := getPage(ctx, client, u, page, &policies)
+		if err != nil {
+			// environments without custom branch policies answer with 404
+			log.Println(err)
+			return resources
+		}
+		for _, policy := range policies.BranchPolicies {
+			resources = append(resources, terraformutils.NewResource(
+				repo.GetName()+":"+env.Name+":"+strconv.FormatInt(policy.ID, 10),
+				repo.GetName()+"_"+env.Name+"_"+policy.Name,
+				"github_repository_environment_deployment_policy",
+				"github",
+				map[string]string{
+					"repository":  repo.GetName(),
+					"environment": env.Name,
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return resources
+}
+
+// PostConvertHook for connect between environments and their deployment policies
+func (g *RepositoryEnvironmentsGenerator) PostConvertHook() error {
+	for _, env := range g.Resources {
+		if env.InstanceInfo.Type != "github_repository_environment" {
+			continue
+		}
+		for i, policy := range g.Resources {
+			if policy.InstanceInfo.Type != "github_repository_environment_deployment_policy" {
+				continue
+			}
+			if policy.InstanceState.Attributes["repository"] == env.InstanceState.Attributes["repository"] &&
+				policy.InstanceState.Attributes["environment"] == env.InstanceState.Attributes["environment"] {
+				g.Resources[i].Item["environment"] = "${github_repository_environment." + env.ResourceName + ".environment}"
+			}
+		}
+	}
+	return nil
+}
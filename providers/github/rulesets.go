@@ -0,0 +1,104 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+
+	githubAPI "github.com/google/go-github/v35/github"
+)
+
+type RulesetsGenerator struct {
+	GithubService
+}
+
+type ruleset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// InitResources generates TerraformResources from Github API,
+func (g *RulesetsGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+	owner := g.Args["owner"].(string)
+
+	g.Resources = append(g.Resources, g.createRulesetResources(ctx, client, fmt.Sprintf("orgs/%s/rulesets", owner), nil)...)
+
+	repos, err := g.listRepositories(ctx, client)
+	if err != nil {
+		log.Println(err)
+	}
+	for _, repo := range repos {
+		// organization rulesets are inherited by repositories, they are imported once above
+		u := fmt.Sprintf("repos/%s/%s/rulesets?includes_parents=false", owner, repo.GetName())
+		g.Resources = append(g.Resources, g.createRulesetResources(ctx, client, u, repo)...)
+	}
+	// organization rulesets only know their ID, import them instead of reading an empty state
+	g.SetRefreshStrategy(providerwrapper.RefreshImportUpgradeRead, "github_organization_ruleset")
+	return nil
+}
+
+// createRulesetResources creates organization rulesets when repo is nil, repository rulesets otherwise
+func (g *RulesetsGenerator) createRulesetResources(ctx context.Context, client *githubAPI.Client, u string, repo *githubAPI.Repository) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	page := 1
+	for {
+		var rulesets []ruleset
+		resp, err := getPage(ctx, client, u, page, &rulesets)
+		if err != nil {
+			log.Println(err)
+			return resources
+		}
+		for _, r := range rulesets {
+			id := strconv.FormatInt(r.ID, 10)
+			if repo == nil {
+				resources = append(resources, terraformutils.NewSimpleResource(
+					id,
+					r.Name,
+					"github_organization_ruleset",
+					"github",
+					[]string{},
+				))
+				continue
+			}
+			resources = append(resources, terraformutils.NewResource(
+				id,
+				repo.GetName()+"_"+r.Name,
+				"github_repository_ruleset",
+				"github",
+				map[string]string{
+					"repository": repo.GetName(),
+				},
+				[]string{},
+				map[string]interface{}{},
+			))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return resources
+}
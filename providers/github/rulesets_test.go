@@ -0,0 +1,39 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/providers/github"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest"
+)
+
+// TestRulesetsGolden covers organization rulesets and the rulesets of each repository
+func TestRulesetsGolden(t *testing.T) {
+	token := "test-token"
+	if os.Getenv(terraformertest.RecordEnv) == "1" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	recorder := terraformertest.NewRecorder(t, "testdata/rulesets/cassette.json")
+	terraformertest.UseDefaultTransport(t, recorder)
+
+	terraformertest.Golden(t, &github.GithubProvider{}, []string{"acme", token, "https://api.github.com/"}, terraformer.Options{
+		Resources: []string{"rulesets"},
+		Plugin:    terraformertest.NewFakeProvider(t, "testdata/rulesets/schema.json", "testdata/rulesets/objects.json"),
+	}, "testdata/rulesets/golden")
+}
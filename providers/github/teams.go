@@ -40,6 +40,19 @@ func (g *TeamsGenerator) createTeamsResources(ctx context.Context, teams []*gith
 		)
 		resource.SlowQueryRequired = true
 		resources = append(resources, resource)
+		resources = append(resources, terraformutils.NewResource(
+			team.GetNodeID(),
+			team.GetName(),
+			"github_team_settings",
+			"github",
+			map[string]string{
+				"team_id":   strconv.FormatInt(team.GetID(), 10),
+				"team_slug": team.GetSlug(),
+				"team_uid":  team.GetNodeID(),
+			},
+			[]string{},
+			map[string]interface{}{},
+		))
 		resources = append(resources, g.createTeamMembersResources(ctx, team, client)...)
 		resources = append(resources, g.createTeamRepositoriesResources(ctx, team, client)...)
 	}
@@ -84,7 +97,7 @@ func (g *TeamsGenerator) createTeamRepositoriesResources(ctx context.Context, te
 
 // InitResources generates TerraformResources from Github API,
 func (g *TeamsGenerator) InitResources() error {
-	ctx := context.Background()
+	ctx := g.Context()
 	client, err := g.createClient()
 	if err != nil {
 		return err
@@ -124,6 +137,14 @@ func (g *TeamsGenerator) PostConvertHook() error {
 				g.Resources[i].Item["team_id"] = "${github_team." + team.ResourceName + ".id}"
 			}
 		}
+		for i, settings := range g.Resources {
+			if settings.InstanceInfo.Type != "github_team_settings" {
+				continue
+			}
+			if settings.InstanceState.Attributes["team_uid"] == team.InstanceState.Attributes["node_id"] {
+				g.Resources[i].Item["team_id"] = "${github_team." + team.ResourceName + ".id}"
+			}
+		}
 		for i, repo := range g.Resources {
 			if repo.InstanceInfo.Type != "github_team_repository" {
 				continue
@@ -0,0 +1,5 @@
+resource "github_actions_organization_secret" "tfer--DEPLOY_KEY" {
+  plaintext_value = "${var.tfer--DEPLOY_KEY_plaintext_value}"
+  secret_name     = "DEPLOY_KEY"
+  visibility      = "private"
+}
@@ -0,0 +1,11 @@
+resource "github_actions_organization_variable" "tfer--REGION" {
+  value         = "eu-west-1"
+  variable_name = "REGION"
+  visibility    = "all"
+}
+
+resource "github_actions_organization_variable" "tfer--STAGE" {
+  value         = "prod"
+  variable_name = "STAGE"
+  visibility    = "all"
+}
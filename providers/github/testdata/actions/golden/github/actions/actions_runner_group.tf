@@ -0,0 +1,7 @@
+resource "github_actions_runner_group" "tfer--builders" {
+  allows_public_repositories = "false"
+  name                       = "builders"
+  restricted_to_workflows    = "false"
+  selected_repository_ids    = ["1296269"]
+  visibility                 = "selected"
+}
@@ -0,0 +1,5 @@
+resource "github_actions_secret" "tfer--infra_TF_TOKEN" {
+  plaintext_value = "${var.tfer--infra_TF_TOKEN_plaintext_value}"
+  repository      = "infra"
+  secret_name     = "TF_TOKEN"
+}
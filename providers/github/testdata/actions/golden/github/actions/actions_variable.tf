@@ -0,0 +1,5 @@
+resource "github_actions_variable" "tfer--infra_TF_VERSION" {
+  repository    = "infra"
+  value         = "1.5.7"
+  variable_name = "TF_VERSION"
+}
@@ -0,0 +1,23 @@
+output "github_actions_organization_secret_tfer--DEPLOY_KEY_id" {
+  value = "${github_actions_organization_secret.tfer--DEPLOY_KEY.id}"
+}
+
+output "github_actions_organization_variable_tfer--REGION_id" {
+  value = "${github_actions_organization_variable.tfer--REGION.id}"
+}
+
+output "github_actions_organization_variable_tfer--STAGE_id" {
+  value = "${github_actions_organization_variable.tfer--STAGE.id}"
+}
+
+output "github_actions_runner_group_tfer--builders_id" {
+  value = "${github_actions_runner_group.tfer--builders.id}"
+}
+
+output "github_actions_secret_tfer--infra_TF_TOKEN_id" {
+  value = "${github_actions_secret.tfer--infra_TF_TOKEN.id}"
+}
+
+output "github_actions_variable_tfer--infra_TF_VERSION_id" {
+  value = "${github_actions_variable.tfer--infra_TF_VERSION.id}"
+}
@@ -0,0 +1,9 @@
+variable "tfer--DEPLOY_KEY_plaintext_value" {
+  description = "Value of secret DEPLOY_KEY"
+  sensitive   = true
+}
+
+variable "tfer--infra_TF_TOKEN_plaintext_value" {
+  description = "Value of secret infra:TF_TOKEN"
+  sensitive   = true
+}
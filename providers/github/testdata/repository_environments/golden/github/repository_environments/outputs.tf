@@ -0,0 +1,11 @@
+output "github_repository_environment_deployment_policy_tfer--infra_production_release-002F--002A-_id" {
+  value = "${github_repository_environment_deployment_policy.tfer--infra_production_release-002F--002A-.id}"
+}
+
+output "github_repository_environment_tfer--infra_production_id" {
+  value = "${github_repository_environment.tfer--infra_production.id}"
+}
+
+output "github_repository_environment_tfer--infra_staging_id" {
+  value = "${github_repository_environment.tfer--infra_staging.id}"
+}
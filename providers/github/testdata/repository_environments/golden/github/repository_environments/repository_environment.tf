@@ -0,0 +1,26 @@
+resource "github_repository_environment" "tfer--infra_production" {
+  can_admins_bypass = "false"
+
+  deployment_branch_policy {
+    custom_branch_policies = "true"
+    protected_branches     = "false"
+  }
+
+  environment         = "production"
+  prevent_self_review = "true"
+  repository          = "infra"
+
+  reviewers {
+    teams = ["1"]
+  }
+
+  wait_timer = "10"
+}
+
+resource "github_repository_environment" "tfer--infra_staging" {
+  can_admins_bypass   = "true"
+  environment         = "staging"
+  prevent_self_review = "false"
+  repository          = "infra"
+  wait_timer          = "0"
+}
@@ -0,0 +1,5 @@
+resource "github_repository_environment_deployment_policy" "tfer--infra_production_release-002F--002A-" {
+  branch_pattern = "release/*"
+  environment    = "${github_repository_environment.tfer--infra_production.environment}"
+  repository     = "infra"
+}
@@ -0,0 +1,17 @@
+resource "github_organization_ruleset" "tfer--protect-main" {
+  conditions {
+    ref_name {
+      include = ["~DEFAULT_BRANCH"]
+    }
+  }
+
+  enforcement = "active"
+  name        = "protect-main"
+
+  rules {
+    deletion         = "true"
+    non_fast_forward = "true"
+  }
+
+  target = "branch"
+}
@@ -0,0 +1,11 @@
+output "github_organization_ruleset_tfer--protect-main_id" {
+  value = "${github_organization_ruleset.tfer--protect-main.id}"
+}
+
+output "github_repository_ruleset_tfer--infra_tags_id" {
+  value = "${github_repository_ruleset.tfer--infra_tags.id}"
+}
+
+output "github_repository_ruleset_tfer--web_protect-main_id" {
+  value = "${github_repository_ruleset.tfer--web_protect-main.id}"
+}
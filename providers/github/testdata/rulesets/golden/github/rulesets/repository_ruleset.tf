@@ -0,0 +1,36 @@
+resource "github_repository_ruleset" "tfer--infra_tags" {
+  conditions {
+    ref_name {
+      include = ["refs/tags/v*"]
+    }
+  }
+
+  enforcement = "evaluate"
+  name        = "tags"
+  repository  = "infra"
+
+  rules {
+    creation = "true"
+    deletion = "true"
+  }
+
+  target = "tag"
+}
+
+resource "github_repository_ruleset" "tfer--web_protect-main" {
+  conditions {
+    ref_name {
+      include = ["~DEFAULT_BRANCH"]
+    }
+  }
+
+  enforcement = "active"
+  name        = "protect-main"
+  repository  = "web"
+
+  rules {
+    non_fast_forward = "true"
+  }
+
+  target = "branch"
+}
@@ -0,0 +1,15 @@
+output "github_team_membership_tfer--Platform_octocat_id" {
+  value = "${github_team_membership.tfer--Platform_octocat.id}"
+}
+
+output "github_team_repository_tfer--Platform_infra_id" {
+  value = "${github_team_repository.tfer--Platform_infra.id}"
+}
+
+output "github_team_settings_tfer--Platform_id" {
+  value = "${github_team_settings.tfer--Platform.id}"
+}
+
+output "github_team_tfer--Platform_id" {
+  value = "${github_team.tfer--Platform.id}"
+}
@@ -0,0 +1,6 @@
+resource "github_team" "tfer--Platform" {
+  create_default_maintainer = "false"
+  description               = "Platform team"
+  name                      = "Platform"
+  privacy                   = "closed"
+}
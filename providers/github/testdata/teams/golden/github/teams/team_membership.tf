@@ -0,0 +1,5 @@
+resource "github_team_membership" "tfer--Platform_octocat" {
+  role     = "maintainer"
+  team_id  = "${github_team.tfer--Platform.id}"
+  username = "octocat"
+}
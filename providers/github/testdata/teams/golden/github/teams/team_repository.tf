@@ -0,0 +1,5 @@
+resource "github_team_repository" "tfer--Platform_infra" {
+  permission = "push"
+  repository = "infra"
+  team_id    = "${github_team.tfer--Platform.id}"
+}
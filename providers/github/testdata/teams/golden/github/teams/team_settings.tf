@@ -0,0 +1,9 @@
+resource "github_team_settings" "tfer--Platform" {
+  review_request_delegation {
+    algorithm    = "ROUND_ROBIN"
+    member_count = "2"
+    notify       = "true"
+  }
+
+  team_id = "${github_team.tfer--Platform.id}"
+}
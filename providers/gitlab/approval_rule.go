@@ -0,0 +1,92 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type ApprovalRuleGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *ApprovalRuleGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createApprovalRules(ctx, client, project)...)
+	}
+	return nil
+}
+
+func createApprovalRules(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+
+	resource := terraformutils.NewResource(
+		strconv.FormatInt(int64(project.ID), 10),
+		getProjectResourceName(project),
+		"gitlab_project_level_mr_approvals",
+		"gitlab",
+		map[string]string{
+			"project": fmt.Sprintf("%d", project.ID),
+		},
+		[]string{},
+		map[string]interface{}{},
+	)
+	resource.SlowQueryRequired = true
+	resources = append(resources, resource)
+
+	rules, _, err := client.Projects.GetProjectApprovalRules(project.ID, gitlab.WithContext(ctx))
+	if err != nil {
+		log.Println(err)
+		return resources
+	}
+	for _, rule := range rules {
+		// the "any_approver" rule is managed through gitlab_project_level_mr_approvals
+		if rule.RuleType == "any_approver" {
+			continue
+		}
+		resource := terraformutils.NewResource(
+			fmt.Sprintf("%d:%d", project.ID, rule.ID),
+			fmt.Sprintf("%s___%s", getProjectResourceName(project), rule.Name),
+			"gitlab_project_approval_rule",
+			"gitlab",
+			map[string]string{
+				"project": fmt.Sprintf("%d", project.ID),
+			},
+			[]string{},
+			map[string]interface{}{},
+		)
+		resource.SlowQueryRequired = true
+		resources = append(resources, resource)
+	}
+	return resources
+}
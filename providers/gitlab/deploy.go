@@ -0,0 +1,182 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type DeployKeyGenerator struct {
+	GitLabService
+}
+
+type DeployTokenGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *DeployKeyGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createDeployKeys(ctx, client, project)...)
+	}
+	return nil
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *DeployTokenGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.Groups.GetGroup(g.Args["group"].(string), gitlab.WithContext(ctx))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	g.Resources = append(g.Resources, createGroupDeployTokens(ctx, client, group)...)
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createProjectDeployTokens(ctx, client, project)...)
+	}
+	return nil
+}
+
+func createDeployKeys(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListProjectDeployKeysOptions{}
+
+	for {
+		deployKeys, resp, err := client.DeployKeys.ListProjectDeployKeys(project.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, deployKey := range deployKeys {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", project.ID, deployKey.ID),
+				fmt.Sprintf("%s___%s", getProjectResourceName(project), deployKey.Title),
+				"gitlab_deploy_key",
+				"gitlab",
+				map[string]string{
+					"project": fmt.Sprintf("%d", project.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+func createProjectDeployTokens(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListProjectDeployTokensOptions{}
+
+	for {
+		deployTokens, resp, err := client.DeployTokens.ListProjectDeployTokens(project.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, deployToken := range deployTokens {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("project:%d:%d", project.ID, deployToken.ID),
+				fmt.Sprintf("%s___%s", getProjectResourceName(project), deployToken.Name),
+				"gitlab_deploy_token",
+				"gitlab",
+				map[string]string{
+					"project": fmt.Sprintf("%d", project.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+func createGroupDeployTokens(ctx context.Context, client *gitlab.Client, group *gitlab.Group) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListGroupDeployTokensOptions{}
+
+	for {
+		deployTokens, resp, err := client.DeployTokens.ListGroupDeployTokens(group.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, deployToken := range deployTokens {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("group:%d:%d", group.ID, deployToken.ID),
+				fmt.Sprintf("%s___%s", getGroupResourceName(group), deployToken.Name),
+				"gitlab_deploy_token",
+				"gitlab",
+				map[string]string{
+					"group": fmt.Sprintf("%d", group.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
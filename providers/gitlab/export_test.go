@@ -0,0 +1,32 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// UseHTTPClient makes the services send their requests through client until t ends
+func UseHTTPClient(t testing.TB, client *http.Client) {
+	newClient = func(token string, options ...gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+		return gitlab.NewClient(token, append(options, gitlab.WithHTTPClient(client))...)
+	}
+	t.Cleanup(func() {
+		newClient = gitlab.NewClient
+	})
+}
@@ -30,7 +30,23 @@ type GitLabProvider struct { //nolint
 }
 
 func (p GitLabProvider) GetResourceConnections() map[string]map[string][]string {
-	return map[string]map[string][]string{}
+	return map[string]map[string][]string{
+		"pipeline_schedules": {"projects": []string{"project", "id"}},
+		"deploy_keys":        {"projects": []string{"project", "id"}},
+		"deploy_tokens": {
+			"projects": []string{"project", "id"},
+			"groups":   []string{"group", "id"},
+		},
+		"hooks": {
+			"projects": []string{"project", "id"},
+			"groups":   []string{"group", "id"},
+		},
+		"approval_rules": {"projects": []string{"project", "id"}},
+		"labels": {
+			"projects": []string{"project", "id"},
+			"groups":   []string{"group", "id"},
+		},
+	}
 }
 
 func (p GitLabProvider) GetProviderData(arg ...string) map[string]interface{} {
@@ -77,6 +93,10 @@ func (p *GitLabProvider) GetName() string {
 	return "gitlab"
 }
 
+func (p *GitLabProvider) GetSource() string {
+	return "gitlabhq/gitlab"
+}
+
 func (p *GitLabProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -97,7 +117,14 @@ func (p *GitLabProvider) InitService(serviceName string, verbose bool) error {
 // GetSupportedService return map of support service for gitlab
 func (p *GitLabProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
 	return map[string]terraformutils.ServiceGenerator{
-		"projects": &ProjectGenerator{},
-		"groups":   &GroupGenerator{},
+		"projects":           &ProjectGenerator{},
+		"groups":             &GroupGenerator{},
+		"pipeline_schedules": &PipelineScheduleGenerator{},
+		"deploy_keys":        &DeployKeyGenerator{},
+		"deploy_tokens":      &DeployTokenGenerator{},
+		"hooks":              &HookGenerator{},
+		"approval_rules":     &ApprovalRuleGenerator{},
+		"labels":             &LabelGenerator{},
+		"instance_variables": &InstanceVariableGenerator{},
 	}
 }
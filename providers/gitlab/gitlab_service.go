@@ -15,6 +15,8 @@
 package gitlab
 
 import (
+	"context"
+
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/xanzy/go-gitlab"
 )
@@ -33,9 +35,36 @@ func (g *GitLabService) createClient() (*gitlab.Client, error) {
 }
 
 func (g *GitLabService) createRegularClient() (*gitlab.Client, error) {
-	return gitlab.NewClient(g.Args["token"].(string))
+	return newClient(g.Args["token"].(string))
 }
 
 func (g *GitLabService) createEnterpriseClient() (*gitlab.Client, error) {
-	return gitlab.NewClient(g.Args["token"].(string), gitlab.WithBaseURL(g.GetArgs()["base_url"].(string)))
+	return newClient(g.Args["token"].(string), gitlab.WithBaseURL(g.GetArgs()["base_url"].(string)))
+}
+
+// newClient creates the API client of every service, tests replace it to replay recorded traffic
+var newClient = gitlab.NewClient
+
+// listProjects returns all projects of group
+func listProjects(ctx context.Context, client *gitlab.Client, group string) ([]*gitlab.Project, error) {
+	var projects []*gitlab.Project
+	opt := &gitlab.ListGroupProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	for {
+		groupProjects, resp, err := client.Groups.ListGroupProjects(group, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, groupProjects...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return projects, nil
 }
@@ -0,0 +1,96 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/providers/gitlab"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+func TestInitResources(t *testing.T) {
+	testCases := map[string]struct {
+		generator terraformutils.ServiceGenerator
+		ids       []string
+	}{
+		"pipeline_schedules": {
+			generator: &gitlab.PipelineScheduleGenerator{},
+			ids:       []string{"gitlab_pipeline_schedule 42:7", "gitlab_pipeline_schedule_variable 42:7:DEPLOY_ENV"},
+		},
+		"approval_rules": {
+			generator: &gitlab.ApprovalRuleGenerator{},
+			ids:       []string{"gitlab_project_level_mr_approvals 42", "gitlab_project_approval_rule 42:2"},
+		},
+		"labels": {
+			generator: &gitlab.LabelGenerator{},
+			ids:       []string{"gitlab_group_label 5:11", "gitlab_project_label 42:12"},
+		},
+		"hooks": {
+			generator: &gitlab.HookGenerator{},
+			ids:       []string{"gitlab_group_hook 5:21", "gitlab_group_hook 5:22", "gitlab_project_hook 42:3"},
+		},
+		"deploy_keys": {
+			generator: &gitlab.DeployKeyGenerator{},
+			ids:       []string{"gitlab_deploy_key 42:4"},
+		},
+		"deploy_tokens": {
+			generator: &gitlab.DeployTokenGenerator{},
+			ids:       []string{"gitlab_deploy_token group:5:9", "gitlab_deploy_token project:42:8"},
+		},
+		"instance_variables": {
+			generator: &gitlab.InstanceVariableGenerator{},
+			ids:       []string{"gitlab_instance_variable SHARED_RUNNER_TOKEN"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			args := map[string]interface{}{
+				"group":    "team",
+				"token":    "test-token",
+				"base_url": "https://gitlab.example.com/api/v4/",
+			}
+			if os.Getenv(terraformertest.RecordEnv) == "1" {
+				args["group"] = os.Getenv("GITLAB_GROUP")
+				args["token"] = os.Getenv("GITLAB_TOKEN")
+				args["base_url"] = os.Getenv("GITLAB_BASE_URL")
+			}
+			recorder := terraformertest.NewRecorder(t, "testdata/"+name+"/cassette.json")
+			gitlab.UseHTTPClient(t, recorder.Client())
+
+			tc.generator.SetArgs(args)
+			tc.generator.SetContext(context.Background())
+			if err := tc.generator.InitResources(); err != nil {
+				t.Fatal(err)
+			}
+			if ids := resourceIDs(tc.generator.GetResources()); !reflect.DeepEqual(ids, tc.ids) {
+				t.Errorf("got %v, want %v", ids, tc.ids)
+			}
+		})
+	}
+}
+
+func resourceIDs(resources []terraformutils.Resource) []string {
+	var ids []string
+	for _, r := range resources {
+		ids = append(ids, r.InstanceInfo.Type+" "+r.InstanceState.ID)
+	}
+	return ids
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type HookGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *HookGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.Groups.GetGroup(g.Args["group"].(string), gitlab.WithContext(ctx))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	g.Resources = append(g.Resources, createGroupHooks(ctx, client, group)...)
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createProjectHooks(ctx, client, project)...)
+	}
+	return nil
+}
+
+func createProjectHooks(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListProjectHooksOptions{}
+
+	for {
+		hooks, resp, err := client.Projects.ListProjectHooks(project.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, hook := range hooks {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", project.ID, hook.ID),
+				fmt.Sprintf("%s___%d", getProjectResourceName(project), hook.ID),
+				"gitlab_project_hook",
+				"gitlab",
+				map[string]string{
+					"project": fmt.Sprintf("%d", project.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+func createGroupHooks(ctx context.Context, client *gitlab.Client, group *gitlab.Group) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListOptions{}
+
+	for {
+		hooks, resp, err := listGroupHooks(ctx, client, group, opt)
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, hook := range hooks {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", group.ID, hook.ID),
+				fmt.Sprintf("%s___%d", getGroupResourceName(group), hook.ID),
+				"gitlab_group_hook",
+				"gitlab",
+				map[string]string{
+					"group": fmt.Sprintf("%d", group.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+// listGroupHooks lists a page of group hooks, Groups.ListGroupHooks of the client takes neither
+// list options nor a context
+func listGroupHooks(ctx context.Context, client *gitlab.Client, group *gitlab.Group, opt *gitlab.ListOptions) ([]*gitlab.GroupHook, *gitlab.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("groups/%d/hooks", group.ID), opt, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, nil, err
+	}
+	var hooks []*gitlab.GroupHook
+	resp, err := client.Do(req, &hooks)
+	if err != nil {
+		return nil, resp, err
+	}
+	return hooks, resp, nil
+}
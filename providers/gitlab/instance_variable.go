@@ -0,0 +1,70 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"log"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type InstanceVariableGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *InstanceVariableGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	g.Resources = append(g.Resources, createInstanceVariables(ctx, client)...)
+	return nil
+}
+
+func createInstanceVariables(ctx context.Context, client *gitlab.Client) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListInstanceVariablesOptions{}
+
+	for {
+		variables, resp, err := client.InstanceVariables.ListVariables(opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, variable := range variables {
+			resource := terraformutils.NewSimpleResource(
+				variable.Key,
+				variable.Key,
+				"gitlab_instance_variable",
+				"gitlab",
+				[]string{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
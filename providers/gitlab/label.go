@@ -0,0 +1,125 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type LabelGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *LabelGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	group, _, err := client.Groups.GetGroup(g.Args["group"].(string), gitlab.WithContext(ctx))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	g.Resources = append(g.Resources, createGroupLabels(ctx, client, group)...)
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createProjectLabels(ctx, client, project)...)
+	}
+	return nil
+}
+
+func createProjectLabels(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	// group labels are imported once as gitlab_group_label
+	opt := &gitlab.ListLabelsOptions{IncludeAncestorGroups: gitlab.Bool(false)}
+
+	for {
+		labels, resp, err := client.Labels.ListLabels(project.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, label := range labels {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", project.ID, label.ID),
+				fmt.Sprintf("%s___%s", getProjectResourceName(project), label.Name),
+				"gitlab_project_label",
+				"gitlab",
+				map[string]string{
+					"project": fmt.Sprintf("%d", project.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+func createGroupLabels(ctx context.Context, client *gitlab.Client, group *gitlab.Group) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListGroupLabelsOptions{}
+
+	for {
+		labels, resp, err := client.GroupLabels.ListGroupLabels(group.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, label := range labels {
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", group.ID, label.ID),
+				fmt.Sprintf("%s___%s", getGroupResourceName(group), label.Name),
+				"gitlab_group_label",
+				"gitlab",
+				map[string]string{
+					"group": fmt.Sprintf("%d", group.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
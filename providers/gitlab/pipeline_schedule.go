@@ -0,0 +1,125 @@
+// Copyright 2020 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/xanzy/go-gitlab"
+)
+
+type PipelineScheduleGenerator struct {
+	GitLabService
+}
+
+// Generate TerraformResources from gitlab API,
+func (g *PipelineScheduleGenerator) InitResources() error {
+	ctx := g.Context()
+	client, err := g.createClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := listProjects(ctx, client, g.Args["group"].(string))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	for _, project := range projects {
+		g.Resources = append(g.Resources, createPipelineSchedules(ctx, client, project)...)
+	}
+	return nil
+}
+
+func createPipelineSchedules(ctx context.Context, client *gitlab.Client, project *gitlab.Project) []terraformutils.Resource {
+	resources := []terraformutils.Resource{}
+	opt := &gitlab.ListPipelineSchedulesOptions{}
+
+	for {
+		schedules, resp, err := client.PipelineSchedules.ListPipelineSchedules(project.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+
+		for _, schedule := range schedules {
+			resourceName := fmt.Sprintf("%s___%d", getProjectResourceName(project), schedule.ID)
+			resource := terraformutils.NewResource(
+				fmt.Sprintf("%d:%d", project.ID, schedule.ID),
+				resourceName,
+				"gitlab_pipeline_schedule",
+				"gitlab",
+				map[string]string{
+					"project": fmt.Sprintf("%d", project.ID),
+				},
+				[]string{},
+				map[string]interface{}{},
+			)
+			resource.SlowQueryRequired = true
+			resources = append(resources, resource)
+
+			// variables are only returned for a single pipeline schedule
+			details, _, err := client.PipelineSchedules.GetPipelineSchedule(project.ID, schedule.ID, gitlab.WithContext(ctx))
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			for _, variable := range details.Variables {
+				resource := terraformutils.NewResource(
+					fmt.Sprintf("%d:%d:%s", project.ID, schedule.ID, variable.Key),
+					fmt.Sprintf("%s___%s", resourceName, variable.Key),
+					"gitlab_pipeline_schedule_variable",
+					"gitlab",
+					map[string]string{
+						"project":              fmt.Sprintf("%d", project.ID),
+						"pipeline_schedule_id": fmt.Sprintf("%d", schedule.ID),
+					},
+					[]string{},
+					map[string]interface{}{},
+				)
+				resource.SlowQueryRequired = true
+				resources = append(resources, resource)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return resources
+}
+
+// PostConvertHook for connect between pipeline schedules and their variables
+func (g *PipelineScheduleGenerator) PostConvertHook() error {
+	for _, schedule := range g.Resources {
+		if schedule.InstanceInfo.Type != "gitlab_pipeline_schedule" {
+			continue
+		}
+		for i, variable := range g.Resources {
+			if variable.InstanceInfo.Type != "gitlab_pipeline_schedule_variable" {
+				continue
+			}
+			if variable.InstanceState.Attributes["project"] == schedule.InstanceState.Attributes["project"] &&
+				fmt.Sprintf("%s:%s", variable.InstanceState.Attributes["project"], variable.InstanceState.Attributes["pipeline_schedule_id"]) == schedule.InstanceState.ID {
+				g.Resources[i].Item["pipeline_schedule_id"] = "${gitlab_pipeline_schedule." + schedule.ResourceName + ".pipeline_schedule_id}"
+			}
+		}
+	}
+	return nil
+}
@@ -49,6 +49,10 @@ func (p *GmailfilterProvider) GetName() string {
 	return "gmailfilter"
 }
 
+func (p *GmailfilterProvider) GetSource() string {
+	return "yamamoto-febc/gmailfilter"
+}
+
 func (p *GmailfilterProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
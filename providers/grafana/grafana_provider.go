@@ -102,6 +102,10 @@ func (p *GrafanaProvider) GetName() string {
 	return "grafana"
 }
 
+func (p *GrafanaProvider) GetSource() string {
+	return "grafana/grafana"
+}
+
 func (p *GrafanaProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -46,6 +46,10 @@ func (p *HoneycombProvider) GetName() string {
 	return "honeycombio"
 }
 
+func (p *HoneycombProvider) GetSource() string {
+	return "honeycombio/honeycombio"
+}
+
 // This mapping will stop working if queries/query annotations are generated as
 // sub-resources of boards or triggers
 func (p HoneycombProvider) GetResourceConnections() map[string]map[string][]string {
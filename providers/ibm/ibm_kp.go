@@ -15,7 +15,6 @@
 package ibm
 
 import (
-	"context"
 	"fmt"
 	"os"
 
@@ -145,7 +144,7 @@ func (g *KPGenerator) InitResources() error {
 		resourceName := g.Resources[len(g.Resources)-1:][0].ResourceName
 		client.Config.InstanceID = kpInstance.Guid
 
-		output, err := client.GetKeys(context.Background(), 100, 0)
+		output, err := client.GetKeys(g.Context(), 100, 0)
 		if err != nil {
 			return err
 		}
@@ -165,7 +164,7 @@ func (g *KPGenerator) InitResources() error {
 				g.Resources = append(g.Resources, fnObjt(key.CRN, alias, dependsOn))
 			}
 
-			policies, _ := client.GetPolicies(context.Background(), key.ID)
+			policies, _ := client.GetPolicies(g.Context(), key.ID)
 			funObjt := g.loadKpKeyPolicies()
 			for range policies {
 				g.Resources = append(g.Resources, funObjt(key.CRN, dependsOn))
@@ -56,6 +56,10 @@ func (p *IBMProvider) GetName() string {
 	return "ibm"
 }
 
+func (p *IBMProvider) GetSource() string {
+	return "ibm-cloud/ibm"
+}
+
 func (p *IBMProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
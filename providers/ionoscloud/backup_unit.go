@@ -1,7 +1,6 @@
 package ionoscloud
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud/helpers"
@@ -17,7 +16,7 @@ func (g *BackupUnitGenerator) InitResources() error {
 	cloudAPIClient := client.CloudAPIClient
 	resourceType := "ionoscloud_backup_unit"
 
-	backupUnitResponse, _, err := cloudAPIClient.BackupUnitsApi.BackupunitsGet(context.TODO()).Depth(1).Execute()
+	backupUnitResponse, _, err := cloudAPIClient.BackupUnitsApi.BackupunitsGet(g.Context()).Depth(1).Execute()
 	if err != nil {
 		return err
 	}
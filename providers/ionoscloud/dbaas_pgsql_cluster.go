@@ -1,7 +1,6 @@
 package ionoscloud
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud/helpers"
@@ -40,7 +39,7 @@ func (g DBaaSPgSQLClusterGenerator) createResources(
 func (g *DBaaSPgSQLClusterGenerator) InitResources() error {
 	client := g.generateClient()
 	dbaasAPIClient := client.DBaaSPgSQLApiClient
-	output, _, err := dbaasAPIClient.ClustersApi.ClustersGet(context.TODO()).Execute()
+	output, _, err := dbaasAPIClient.ClustersApi.ClustersGet(g.Context()).Execute()
 	if err != nil {
 		return err
 	}
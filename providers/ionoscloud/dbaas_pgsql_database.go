@@ -1,7 +1,6 @@
 package ionoscloud
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud/helpers"
@@ -17,7 +16,7 @@ func (g *DBaaSPgSQLDatabaseGenerator) InitResources() error {
 	dbaasPgSQLClient := client.DBaaSPgSQLApiClient
 	resourceType := "ionoscloud_pg_database"
 
-	response, _, err := dbaasPgSQLClient.ClustersApi.ClustersGet(context.TODO()).Execute()
+	response, _, err := dbaasPgSQLClient.ClustersApi.ClustersGet(g.Context()).Execute()
 	if err != nil {
 		return err
 	}
@@ -26,7 +25,7 @@ func (g *DBaaSPgSQLDatabaseGenerator) InitResources() error {
 	}
 	clusters := *response.Items
 	for _, cluster := range clusters {
-		databasesResponse, _, err := dbaasPgSQLClient.DatabasesApi.DatabasesList(context.TODO(), *cluster.Id).Execute()
+		databasesResponse, _, err := dbaasPgSQLClient.DatabasesApi.DatabasesList(g.Context(), *cluster.Id).Execute()
 		if err != nil {
 			return err
 		}
@@ -7,8 +7,8 @@ import (
 	ionoscloud "github.com/ionos-cloud/sdk-go/v6"
 )
 
-func GetAllDatacenters(client ionoscloud.APIClient) ([]ionoscloud.Datacenter, error) {
-	datacenters, _, err := client.DataCentersApi.DatacentersGet(context.TODO()).Depth(1).Execute()
+func GetAllDatacenters(ctx context.Context, client ionoscloud.APIClient) ([]ionoscloud.Datacenter, error) {
+	datacenters, _, err := client.DataCentersApi.DatacentersGet(ctx).Depth(1).Execute()
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +60,10 @@ func (p *IonosCloudProvider) GetName() string {
 	return helpers.ProviderName
 }
 
+func (p *IonosCloudProvider) GetSource() string {
+	return "ionos-cloud/" + helpers.ProviderName
+}
+
 func (p *IonosCloudProvider) GetProviderData(_ ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -1,7 +1,6 @@
 package ionoscloud
 
 import (
-	"context"
 	"log"
 
 	"github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud/helpers"
@@ -17,7 +16,7 @@ func (g *KubernetesClusterGenerator) InitResources() error {
 	cloudAPIClient := client.CloudAPIClient
 	resourceType := "ionoscloud_k8s_cluster"
 
-	kubernetesClusterResponse, _, err := cloudAPIClient.KubernetesApi.K8sGet(context.TODO()).Depth(1).Execute()
+	kubernetesClusterResponse, _, err := cloudAPIClient.KubernetesApi.K8sGet(g.Context()).Depth(1).Execute()
 	if err != nil {
 		return err
 	}
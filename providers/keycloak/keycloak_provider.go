@@ -61,6 +61,10 @@ func (p *KeycloakProvider) GetName() string {
 	return "keycloak"
 }
 
+func (p *KeycloakProvider) GetSource() string {
+	return "mrparkers/keycloak"
+}
+
 func (p *KeycloakProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
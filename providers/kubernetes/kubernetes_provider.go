@@ -60,6 +60,10 @@ func (p *KubernetesProvider) GetName() string {
 	return "kubernetes"
 }
 
+func (p *KubernetesProvider) GetSource() string {
+	return "hashicorp/kubernetes"
+}
+
 func (p *KubernetesProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -62,6 +62,10 @@ func (p *LaunchDarklyProvider) GetName() string {
 	return "launchdarkly"
 }
 
+func (p *LaunchDarklyProvider) GetSource() string {
+	return "launchdarkly/launchdarkly"
+}
+
 func (p *LaunchDarklyProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
@@ -39,6 +39,10 @@ func (p *LinodeProvider) GetName() string {
 	return "linode"
 }
 
+func (p *LinodeProvider) GetSource() string {
+	return "linode/linode"
+}
+
 func (p *LinodeProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -61,6 +61,10 @@ func (p *LogzioProvider) GetName() string {
 	return "logzio"
 }
 
+func (p *LogzioProvider) GetSource() string {
+	return "logzio/logzio"
+}
+
 func (p *LogzioProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
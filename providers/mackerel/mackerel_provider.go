@@ -67,6 +67,10 @@ func (p *MackerelProvider) GetName() string {
 	return "mackerel"
 }
 
+func (p *MackerelProvider) GetSource() string {
+	return "mackerelio-labs/mackerel"
+}
+
 // GetConfig return map of provider config for Mackerel
 func (p *MackerelProvider) GetConfig() cty.Value {
 	return cty.ObjectVal(map[string]cty.Value{
@@ -36,6 +36,10 @@ func (p *MikrotikProvider) GetName() string {
 	return "mikrotik"
 }
 
+func (p *MikrotikProvider) GetSource() string {
+	return "ddelnano/mikrotik"
+}
+
 func (p *MikrotikProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
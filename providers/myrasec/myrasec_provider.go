@@ -27,6 +27,13 @@ func (p *MyrasecProvider) GetName() string {
 	return "myrasec"
 }
 
+//
+// GetSource
+//
+func (p *MyrasecProvider) GetSource() string {
+	return "myra-security-gmbh/myrasec"
+}
+
 //
 // GetProviderData
 //
@@ -65,6 +65,10 @@ func (p *NewRelicProvider) GetName() string {
 	return "newrelic"
 }
 
+func (p *NewRelicProvider) GetSource() string {
+	return "newrelic/newrelic"
+}
+
 func (p *NewRelicProvider) GetConfig() cty.Value {
 	return cty.ObjectVal(map[string]cty.Value{
 		"account_id": cty.NumberIntVal(int64(p.accountID)),
@@ -39,6 +39,10 @@ func (p *Ns1Provider) GetName() string {
 	return "ns1"
 }
 
+func (p *Ns1Provider) GetSource() string {
+	return "ns1-terraform/ns1"
+}
+
 func (p *Ns1Provider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
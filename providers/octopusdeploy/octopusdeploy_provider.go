@@ -42,6 +42,10 @@ func (p *OctopusDeployProvider) GetName() string {
 	return "octopusdeploy"
 }
 
+func (p *OctopusDeployProvider) GetSource() string {
+	return "octopusdeploylabs/octopusdeploy"
+}
+
 func (p *OctopusDeployProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
@@ -72,6 +72,10 @@ func (p *OktaProvider) GetName() string {
 	return "okta"
 }
 
+func (p *OktaProvider) GetSource() string {
+	return "okta/okta"
+}
+
 func (p *OktaProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
@@ -55,6 +55,10 @@ func (p *OpenStackProvider) GetName() string {
 	return "openstack"
 }
 
+func (p *OpenStackProvider) GetSource() string {
+	return "terraform-provider-openstack/openstack"
+}
+
 func (p *OpenStackProvider) InitService(serviceName string, verbose bool) error {
 	var isSupported bool
 	if _, isSupported = p.GetSupportedService()[serviceName]; !isSupported {
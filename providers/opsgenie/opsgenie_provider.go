@@ -62,6 +62,10 @@ func (p *OpsgenieProvider) GetName() string {
 	return "opsgenie"
 }
 
+func (p *OpsgenieProvider) GetSource() string {
+	return "opsgenie/opsgenie"
+}
+
 func (p *OpsgenieProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
 	return map[string]terraformutils.ServiceGenerator{
 		"user":    &UserGenerator{},
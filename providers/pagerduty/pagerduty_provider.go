@@ -41,6 +41,10 @@ func (p *PagerDutyProvider) GetName() string {
 	return "pagerduty"
 }
 
+func (p *PagerDutyProvider) GetSource() string {
+	return "pagerduty/pagerduty"
+}
+
 func (p *PagerDutyProvider) GetConfig() cty.Value {
 	return cty.ObjectVal(map[string]cty.Value{
 		"token": cty.StringVal(p.token),
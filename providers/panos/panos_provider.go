@@ -36,6 +36,10 @@ func (p *PanosProvider) GetName() string {
 	return "panos"
 }
 
+func (p *PanosProvider) GetSource() string {
+	return "paloaltonetworks/panos"
+}
+
 func (p *PanosProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -39,6 +39,10 @@ func (p *RBTProvider) GetName() string {
 	return "rabbitmq"
 }
 
+func (p *RBTProvider) GetSource() string {
+	return "cyrilgdn/rabbitmq"
+}
+
 func (p *RBTProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
 	"github.com/zclconf/go-cty/cty"
@@ -11,8 +12,11 @@ import (
 
 type Provider struct {
 	terraformutils.Provider
-	token   string
-	address string
+	token              string
+	address            string
+	maxDepth           int
+	secretsAsVariables bool
+	namespace          string
 }
 
 func (p *Provider) Init(args []string) error {
@@ -33,20 +37,52 @@ func (p *Provider) Init(args []string) error {
 		p.token = args[1]
 	}
 
+	if len(args) > 2 && args[2] != "" {
+		maxDepth, err := strconv.Atoi(args[2])
+		if err != nil {
+			return err
+		}
+		p.maxDepth = maxDepth
+	}
+
+	if len(args) > 3 && args[3] != "" {
+		secretsAsVariables, err := strconv.ParseBool(args[3])
+		if err != nil {
+			return err
+		}
+		p.secretsAsVariables = secretsAsVariables
+	}
+
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		p.namespace = namespace
+	}
+
+	if len(args) > 4 && args[4] != "" {
+		p.namespace = args[4]
+	}
+
 	return nil
 }
 
 func (p *Provider) GetConfig() cty.Value {
-	return cty.ObjectVal(map[string]cty.Value{
+	config := map[string]cty.Value{
 		"token":   cty.StringVal(p.token),
 		"address": cty.StringVal(p.address),
-	})
+	}
+	if p.namespace != "" {
+		config["namespace"] = cty.StringVal(p.namespace)
+	}
+	return cty.ObjectVal(config)
 }
 
 func (p *Provider) GetName() string {
 	return "vault"
 }
 
+func (p *Provider) GetSource() string {
+	return "hashicorp/vault"
+}
+
 func (p *Provider) InitService(serviceName string, verbose bool) error {
 	if service, ok := p.GetSupportedService()[serviceName]; ok {
 		p.Service = service
@@ -54,8 +90,11 @@ func (p *Provider) InitService(serviceName string, verbose bool) error {
 		p.Service.SetVerbose(verbose)
 		p.Service.SetProviderName(p.GetName())
 		p.Service.SetArgs(map[string]interface{}{
-			"token":   p.token,
-			"address": p.address,
+			"token":                p.token,
+			"address":              p.address,
+			"max_depth":            p.maxDepth,
+			"secrets_as_variables": p.secretsAsVariables,
+			"namespace":            p.namespace,
 		})
 		if err := service.(*ServiceGenerator).setVaultClient(); err != nil {
 			return err
@@ -74,6 +113,10 @@ func getSupportedMountServices() map[string]terraformutils.ServiceGenerator {
 		"auth_backend_role":   {"alicloud", "approle", "aws", "azure", "cert", "gcp", "jwt", "kubernetes", "token"},
 		"auth_backend_user":   {"ldap", "okta"},
 		"auth_backend_group":  {"ldap", "okta"},
+
+		"secret_backend_config_urls":    {"pki"},
+		"secret_backend_config_issuers": {"pki"},
+		"secret_backend_issuer":         {"pki"},
 	}
 	for resource, mountTypes := range mapping {
 		for _, mountType := range mountTypes {
@@ -89,6 +132,10 @@ func (p *Provider) GetSupportedService() map[string]terraformutils.ServiceGenera
 	generators["policy"] = &ServiceGenerator{resource: "policy"}
 	generators["mount"] = &ServiceGenerator{resource: "mount"}
 	generators["generic_secret"] = &ServiceGenerator{resource: "generic_secret", mountType: "kv"}
+	generators["kv_secret_backend_v2"] = &ServiceGenerator{resource: "kv_secret_backend_v2", mountType: "kv"}
+	for resource := range listedResources {
+		generators[resource] = &ServiceGenerator{resource: resource}
+	}
 	return generators
 }
 
@@ -96,6 +143,15 @@ func (Provider) GetResourceConnections() map[string]map[string][]string {
 	return map[string]map[string][]string{}
 }
 
-func (Provider) GetProviderData(_ ...string) map[string]interface{} {
-	return map[string]interface{}{}
+func (p Provider) GetProviderData(_ ...string) map[string]interface{} {
+	if p.namespace == "" {
+		return map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"provider": map[string]interface{}{
+			"vault": map[string]interface{}{
+				"namespace": p.namespace,
+			},
+		},
+	}
 }
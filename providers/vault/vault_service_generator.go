@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"regexp"
 	"sort"
 	"strings"
@@ -12,22 +13,66 @@ import (
 	vault "github.com/hashicorp/vault/api"
 )
 
+const (
+	kvVersion1 = "1"
+	kvVersion2 = "2"
+)
+
+const namespaceHeader = "X-Vault-Namespace"
+
+// listedResources maps resources that are listed from a single API path to that path
+var listedResources = map[string]string{
+	"identity_entity":          "identity/entity/id",
+	"identity_entity_alias":    "identity/entity-alias/id",
+	"identity_group":           "identity/group/id",
+	"identity_group_alias":     "identity/group-alias/id",
+	"identity_oidc_assignment": "identity/oidc/assignment",
+	"identity_oidc_client":     "identity/oidc/client",
+	"identity_oidc_key":        "identity/oidc/key",
+	"identity_oidc_provider":   "identity/oidc/provider",
+	"identity_oidc_role":       "identity/oidc/role",
+	"identity_oidc_scope":      "identity/oidc/scope",
+	"namespace":                "sys/namespaces",
+}
+
 type ServiceGenerator struct { //nolint
 	terraformutils.Service
 	client    *vault.Client
 	mountType string
 	resource  string
+	// maxDepth limits how many folder levels kv secrets are listed, 0 means unlimited
+	maxDepth int
+	// secretsAsVariables replaces secret data with variable references in HCL and state
+	secretsAsVariables bool
+}
+
+func newVaultClient(address, token, namespace string) (*vault.Client, error) {
+	client, err := vault.NewClient(&vault.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		client.SetToken(token)
+	}
+	if namespace != "" {
+		client.SetHeaders(http.Header{namespaceHeader: []string{namespace}})
+	}
+	return client, nil
 }
 
 func (g *ServiceGenerator) setVaultClient() error {
-	client, err := vault.NewClient(&vault.Config{Address: g.Args["address"].(string)})
+	namespace, _ := g.Args["namespace"].(string)
+	client, err := newVaultClient(g.Args["address"].(string), g.Args["token"].(string), namespace)
 	if err != nil {
 		return err
 	}
-	if g.Args["token"] != "" {
-		client.SetToken(g.Args["token"].(string))
-	}
 	g.client = client
+	if maxDepth, ok := g.Args["max_depth"].(int); ok {
+		g.maxDepth = maxDepth
+	}
+	if secretsAsVariables, ok := g.Args["secrets_as_variables"].(bool); ok {
+		g.secretsAsVariables = secretsAsVariables
+	}
 	return nil
 }
 
@@ -49,9 +94,20 @@ func (g *ServiceGenerator) InitResources() error {
 		return g.createPolicyResources()
 	case "generic_secret":
 		return g.createGenericSecretResources()
+	case "kv_secret_backend_v2":
+		return g.createKvSecretBackendV2Resources()
 	case "mount":
 		return g.createMountResources()
+	case "secret_backend_config_urls":
+		return g.createSecretBackendConfigResources("config/urls")
+	case "secret_backend_config_issuers":
+		return g.createSecretBackendConfigResources("config/issuers")
+	case "secret_backend_issuer":
+		return g.createSecretBackendIssuerResources()
 	default:
+		if path, ok := listedResources[g.resource]; ok {
+			return g.createListedResources(path)
+		}
 		return errors.New("unsupported service type. shouldn't ever reach here")
 	}
 }
@@ -107,6 +163,139 @@ func (g *ServiceGenerator) createSecretBackendRoleResources() error {
 	return nil
 }
 
+func (g *ServiceGenerator) createSecretBackendConfigResources(configPath string) error {
+	mounts, err := g.mountsByType()
+	if err != nil {
+		return err
+	}
+	for _, mount := range mounts {
+		g.Resources = append(g.Resources,
+			terraformutils.NewSimpleResource(
+				fmt.Sprintf("%s/%s", mount, configPath),
+				mount,
+				fmt.Sprintf("vault_%s_%s", g.mountType, g.resource),
+				g.ProviderName,
+				[]string{}))
+	}
+	return nil
+}
+
+func (g *ServiceGenerator) createSecretBackendIssuerResources() error {
+	mounts, err := g.mountsByType()
+	if err != nil {
+		return err
+	}
+	for _, mount := range mounts {
+		path := fmt.Sprintf("%s/issuers", mount)
+		s, err := g.client.Logical().List(path)
+		if err != nil {
+			log.Printf("error calling path %s: %s", path, err)
+			continue
+		}
+		if s == nil {
+			log.Printf("call to %s returned nil result", path)
+			continue
+		}
+		issuers, ok := s.Data["keys"]
+		if !ok {
+			log.Printf("no keys in call to %s", path)
+			continue
+		}
+		for _, issuer := range issuers.([]interface{}) {
+			name := keyInfoName(s.Data, issuer.(string), "issuer_name")
+			g.Resources = append(g.Resources,
+				terraformutils.NewSimpleResource(
+					fmt.Sprintf("%s/issuer/%s", mount, issuer),
+					fmt.Sprintf("%s_%s", mount, name),
+					fmt.Sprintf("vault_%s_secret_backend_issuer", g.mountType),
+					g.ProviderName,
+					[]string{}))
+		}
+	}
+	return nil
+}
+
+// createListedResources creates a resource for every key listed at path,
+// named after the "name" in key_info when Vault returns one (e.g. identity entities listed by id)
+func (g *ServiceGenerator) createListedResources(path string) error {
+	s, err := g.client.Logical().List(path)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		// the client answers 404 with no secret, other errors fail the import
+		log.Printf("%s was not found, skipping it", path)
+		return nil
+	}
+	keys, ok := s.Data["keys"]
+	if !ok {
+		log.Printf("no keys in call to %s", path)
+		return nil
+	}
+	for _, key := range keys.([]interface{}) {
+		id := strings.TrimSuffix(key.(string), "/")
+		g.Resources = append(g.Resources,
+			terraformutils.NewSimpleResource(
+				id,
+				keyInfoName(s.Data, key.(string), "name"),
+				fmt.Sprintf("vault_%s", g.resource),
+				g.ProviderName,
+				[]string{}))
+	}
+	return nil
+}
+
+// keyInfoName returns the field from key_info of a list response, falling back to key
+func keyInfoName(data map[string]interface{}, key, field string) string {
+	keyInfo, ok := data["key_info"].(map[string]interface{})
+	if !ok {
+		return strings.TrimSuffix(key, "/")
+	}
+	info, ok := keyInfo[key].(map[string]interface{})
+	if !ok {
+		return strings.TrimSuffix(key, "/")
+	}
+	name, ok := info[field].(string)
+	if !ok || name == "" {
+		return strings.TrimSuffix(key, "/")
+	}
+	return name
+}
+
+// ListNamespaces returns namespace and all namespaces nested below it
+func ListNamespaces(address, token, namespace string) ([]string, error) {
+	client, err := newVaultClient(address, token, namespace)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{namespace}
+	s, err := client.Logical().List("sys/namespaces")
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		// not found, namespaces are a Vault Enterprise feature
+		log.Printf("sys/namespaces was not found in namespace %q, skipping its children", namespace)
+		return namespaces, nil
+	}
+	keys, ok := s.Data["keys"]
+	if !ok {
+		return namespaces, nil
+	}
+	for _, key := range keys.([]interface{}) {
+		child := strings.TrimSuffix(key.(string), "/")
+		if namespace != "" {
+			child = namespace + "/" + child
+		}
+		children, err := ListNamespaces(address, token, child)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, children...)
+	}
+	return namespaces, nil
+}
+
 func (g *ServiceGenerator) mountsByType() ([]string, error) {
 	mounts, err := g.client.Sys().ListMounts()
 	if err != nil {
@@ -210,32 +399,29 @@ func (g *ServiceGenerator) createPolicyResources() error {
 }
 
 func (g *ServiceGenerator) createGenericSecretResources() error {
-	mounts, err := g.mountsByType()
+	mounts, err := g.kvMounts()
 	if err != nil {
 		return err
 	}
-	for _, mount := range mounts {
-		path := fmt.Sprintf("%s/", mount)
-		s, err := g.client.Logical().List(path)
-		if err != nil {
-			log.Printf("error calling path %s: %s", path, err)
-			continue
-		}
-		if s == nil {
-			log.Printf("call to %s returned nil result", path)
-			continue
-		}
-		secrets, ok := s.Data["keys"]
-		if !ok {
-			log.Printf("no keys in call to %s", path)
-			continue
+	for mount, version := range mounts {
+		var secrets []string
+		if version == kvVersion2 {
+			secrets = g.listSecretsRecursive(fmt.Sprintf("%s/metadata", mount), "", 1)
+		} else {
+			secrets = g.listSecretsRecursive(mount, "", 1)
 		}
-		for _, secret := range secrets.([]interface{}) {
+		for _, secret := range secrets {
+			id := fmt.Sprintf("%s/%s", mount, secret)
+			resourceType := "vault_generic_secret"
+			if version == kvVersion2 {
+				id = fmt.Sprintf("%s/data/%s", mount, secret)
+				resourceType = "vault_kv_secret_v2"
+			}
 			g.Resources = append(g.Resources,
 				terraformutils.NewSimpleResource(
-					fmt.Sprintf("%s/%s", mount, secret),
+					id,
 					fmt.Sprintf("%s_%s", mount, secret),
-					"vault_generic_secret",
+					resourceType,
 					g.ProviderName,
 					[]string{}))
 		}
@@ -243,6 +429,80 @@ func (g *ServiceGenerator) createGenericSecretResources() error {
 	return nil
 }
 
+func (g *ServiceGenerator) createKvSecretBackendV2Resources() error {
+	mounts, err := g.kvMounts()
+	if err != nil {
+		return err
+	}
+	for mount, version := range mounts {
+		if version != kvVersion2 {
+			continue
+		}
+		g.Resources = append(g.Resources,
+			terraformutils.NewSimpleResource(
+				fmt.Sprintf("%s/config", mount),
+				mount,
+				"vault_kv_secret_backend_v2",
+				g.ProviderName,
+				[]string{}))
+	}
+	return nil
+}
+
+// listSecretsRecursive returns the leaf keys below prefix, relative to base.
+// Keys ending with "/" are folders and are listed again until maxDepth is reached.
+func (g *ServiceGenerator) listSecretsRecursive(base, prefix string, depth int) []string {
+	path := fmt.Sprintf("%s/%s", base, prefix)
+	s, err := g.client.Logical().List(path)
+	if err != nil {
+		log.Printf("error calling path %s: %s", path, err)
+		return nil
+	}
+	if s == nil {
+		log.Printf("call to %s returned nil result", path)
+		return nil
+	}
+	keys, ok := s.Data["keys"]
+	if !ok {
+		log.Printf("no keys in call to %s", path)
+		return nil
+	}
+	var secrets []string
+	for _, key := range keys.([]interface{}) {
+		name := prefix + key.(string)
+		if !strings.HasSuffix(name, "/") {
+			secrets = append(secrets, name)
+			continue
+		}
+		if g.maxDepth > 0 && depth >= g.maxDepth {
+			log.Printf("skipping %s/%s: max depth %d reached", base, name, g.maxDepth)
+			continue
+		}
+		secrets = append(secrets, g.listSecretsRecursive(base, name, depth+1)...)
+	}
+	return secrets
+}
+
+// kvMounts returns kv mounts paths keyed to their kv engine version
+func (g *ServiceGenerator) kvMounts() (map[string]string, error) {
+	mounts, err := g.client.Sys().ListMounts()
+	if err != nil {
+		return nil, err
+	}
+	kvMounts := map[string]string{}
+	for name, mount := range mounts {
+		if mount.Type != "kv" && mount.Type != "generic" {
+			continue
+		}
+		version := kvVersion1
+		if mount.Options["version"] == kvVersion2 {
+			version = kvVersion2
+		}
+		kvMounts[strings.TrimSuffix(name, "/")] = version
+	}
+	return kvMounts, nil
+}
+
 func (g *ServiceGenerator) createMountResources() error {
 	mounts, err := g.mountsByType()
 	if err != nil {
@@ -261,7 +521,8 @@ func (g *ServiceGenerator) createMountResources() error {
 }
 
 func (g *ServiceGenerator) PostConvertHook() error {
-	for _, resource := range g.Resources {
+	for i := range g.Resources {
+		resource := &g.Resources[i]
 		switch resource.InstanceInfo.Type {
 		case "vault_aws_secret_backend_role":
 			if policyDocument, ok := resource.Item["policy_document"]; ok {
@@ -281,7 +542,30 @@ POLICY`, sanitizedPolicy)
 				sort.Strings(strPolicies)
 				resource.Item["policies"] = strPolicies
 			}
+		case "vault_generic_secret", "vault_kv_secret_v2":
+			if g.secretsAsVariables {
+				redactSecretData(resource)
+			}
 		}
 	}
 	return nil
 }
+
+// redactSecretData drops secret values from the resource and its state,
+// data_json is read from a sensitive variable instead.
+func redactSecretData(resource *terraformutils.Resource) {
+	variableName := resource.ResourceName + "_data"
+	resource.Item["data_json"] = fmt.Sprintf("${jsonencode(var.%s)}", variableName)
+	for key := range resource.InstanceState.Attributes {
+		if key == "data_json" || key == "data" || strings.HasPrefix(key, "data.") {
+			delete(resource.InstanceState.Attributes, key)
+		}
+	}
+	if resource.Variables == nil {
+		resource.Variables = map[string]map[string]interface{}{}
+	}
+	resource.Variables[variableName] = map[string]interface{}{
+		"description": fmt.Sprintf("Secret data of %s", resource.InstanceState.ID),
+		"sensitive":   true,
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const unsupportedPath = `{"errors":["1 error occurred:\n\t* unsupported path\n\n"]}`
+
+// fakeResponse is the status and JSON body a fake Vault server answers
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+// newFakeVault serves responses keyed by namespace and path without trailing slash, e.g.
+// "team sys/namespaces", other paths are not found
+func newFakeVault(t *testing.T, responses map[string]fakeResponse) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(namespaceHeader) + " " + strings.TrimSuffix(r.URL.Path[len("/v1/"):], "/")
+		response, exist := responses[key]
+		if !exist {
+			response = fakeResponse{http.StatusNotFound, `{"errors":[]}`}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(response.status)
+		_, _ = w.Write([]byte(response.body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newTestGenerator(t *testing.T, address, resource, mountType string) *ServiceGenerator {
+	g := &ServiceGenerator{resource: resource, mountType: mountType}
+	g.SetProviderName("vault")
+	g.SetArgs(map[string]interface{}{"address": address, "token": "test-token"})
+	if err := g.setVaultClient(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func resourceIDs(resources []terraformutils.Resource) []string {
+	ids := []string{}
+	for _, r := range resources {
+		ids = append(ids, r.InstanceInfo.Type+" "+r.InstanceState.ID+" "+r.ResourceName)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestListNamespaces(t *testing.T) {
+	address := newFakeVault(t, map[string]fakeResponse{
+		" sys/namespaces":       {http.StatusOK, `{"data":{"keys":["team/"]}}`},
+		"team sys/namespaces":   {http.StatusOK, `{"data":{"keys":["a/","b/"]}}`},
+		"team/a sys/namespaces": {http.StatusOK, `{"data":{"keys":["c/"]}}`},
+		"team/b sys/namespaces": {http.StatusNotFound, unsupportedPath},
+	})
+	namespaces, err := ListNamespaces(address, "test-token", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"", "team", "team/a", "team/a/c", "team/b"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("got %v, want %v", namespaces, expected)
+	}
+}
+
+func TestListNamespacesUnsupported(t *testing.T) {
+	address := newFakeVault(t, map[string]fakeResponse{" sys/namespaces": {http.StatusNotFound, unsupportedPath}})
+	namespaces, err := ListNamespaces(address, "test-token", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{""}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("got %v, want %v", namespaces, expected)
+	}
+}
+
+func TestListNamespacesError(t *testing.T) {
+	// only paths that aren't found are empty, e.g. a denied token fails the listing
+	address := newFakeVault(t, map[string]fakeResponse{" sys/namespaces": {http.StatusForbidden, `{"errors":["permission denied"]}`}})
+	if _, err := ListNamespaces(address, "test-token", ""); err == nil {
+		t.Error("expected permission denied")
+	}
+}
+
+func TestCreateListedResources(t *testing.T) {
+	address := newFakeVault(t, map[string]fakeResponse{
+		" identity/entity/id": {http.StatusOK, `{"data":{"keys":["e-1"],"key_info":{"e-1":{"name":"alice"}}}}`},
+		" sys/namespaces":     {http.StatusNotFound, unsupportedPath},
+		" identity/group/id":  {http.StatusBadRequest, `{"errors":["invalid request"]}`},
+	})
+
+	g := newTestGenerator(t, address, "identity_entity", "")
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"vault_identity_entity e-1 tfer--alice"}; !reflect.DeepEqual(resourceIDs(g.Resources), expected) {
+		t.Errorf("got %v, want %v", resourceIDs(g.Resources), expected)
+	}
+
+	g = newTestGenerator(t, address, "namespace", "")
+	if err := g.InitResources(); err != nil {
+		t.Fatalf("namespaces unsupported by the server should be empty: %s", err)
+	}
+	if len(g.Resources) != 0 {
+		t.Errorf("unexpected namespaces %v", resourceIDs(g.Resources))
+	}
+
+	g = newTestGenerator(t, address, "identity_group", "")
+	if err := g.InitResources(); err == nil {
+		t.Error("expected the bad request to fail the import")
+	}
+}
+
+func TestCreateSecretBackendIssuerResources(t *testing.T) {
+	address := newFakeVault(t, map[string]fakeResponse{
+		" sys/mounts": {http.StatusOK, `{"pki/":{"type":"pki"},"pki_int/":{"type":"pki"},"secret/":{"type":"kv"}}`},
+		" pki/issuers": {http.StatusOK, `{"data":{"keys":["1ae8ce9d","7f2b8e1c"],"key_info":{
+			"1ae8ce9d":{"issuer_name":"root-2024"},
+			"7f2b8e1c":{"issuer_name":""}}}}`},
+	})
+
+	g := newTestGenerator(t, address, "secret_backend_issuer", "pki")
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"vault_pki_secret_backend_issuer pki/issuer/1ae8ce9d tfer--pki_root-2024",
+		"vault_pki_secret_backend_issuer pki/issuer/7f2b8e1c tfer--pki_7f2b8e1c",
+	}
+	if ids := resourceIDs(g.Resources); !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, want %v", ids, expected)
+	}
+}
+
+// kvResponses serve a kv v1 mount, a kv v2 mount and a legacy generic mount with nested secrets
+var kvResponses = map[string]fakeResponse{
+	" sys/mounts": {http.StatusOK, `{
+		"secret/":{"type":"kv","options":{"version":"1"}},
+		"kv/":{"type":"kv","options":{"version":"2"}},
+		"legacy/":{"type":"generic"},
+		"pki/":{"type":"pki"}}`},
+	" secret":             {http.StatusOK, `{"data":{"keys":["a"]}}`},
+	" legacy":             {http.StatusOK, `{"data":{"keys":["b"]}}`},
+	" kv/metadata":        {http.StatusOK, `{"data":{"keys":["app/","top"]}}`},
+	" kv/metadata/app":    {http.StatusOK, `{"data":{"keys":["db/","key"]}}`},
+	" kv/metadata/app/db": {http.StatusOK, `{"data":{"keys":["password"]}}`},
+}
+
+func TestCreateGenericSecretResources(t *testing.T) {
+	address := newFakeVault(t, kvResponses)
+	testCases := map[string]struct {
+		maxDepth int
+		ids      []string
+	}{
+		"unlimited": {
+			ids: []string{
+				"vault_generic_secret legacy/b",
+				"vault_generic_secret secret/a",
+				"vault_kv_secret_v2 kv/data/app/db/password",
+				"vault_kv_secret_v2 kv/data/app/key",
+				"vault_kv_secret_v2 kv/data/top",
+			},
+		},
+		"max depth": {
+			maxDepth: 2,
+			ids: []string{
+				"vault_generic_secret legacy/b",
+				"vault_generic_secret secret/a",
+				"vault_kv_secret_v2 kv/data/app/key",
+				"vault_kv_secret_v2 kv/data/top",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			g := newTestGenerator(t, address, "generic_secret", "")
+			g.maxDepth = tc.maxDepth
+			if err := g.InitResources(); err != nil {
+				t.Fatal(err)
+			}
+			if ids := resourceTypesAndIDs(g.Resources); !reflect.DeepEqual(ids, tc.ids) {
+				t.Errorf("got %v, want %v", ids, tc.ids)
+			}
+		})
+	}
+}
+
+func TestCreateKvSecretBackendV2Resources(t *testing.T) {
+	g := newTestGenerator(t, newFakeVault(t, kvResponses), "kv_secret_backend_v2", "")
+	if err := g.InitResources(); err != nil {
+		t.Fatal(err)
+	}
+	if ids, expected := resourceTypesAndIDs(g.Resources), []string{"vault_kv_secret_backend_v2 kv/config"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, want %v", ids, expected)
+	}
+}
+
+func TestRedactSecretData(t *testing.T) {
+	secret := terraformutils.NewSimpleResource("kv/data/app/db", "kv_app_db", "vault_kv_secret_v2", "vault", []string{})
+	secret.InstanceState.Attributes = map[string]string{
+		"id":            "kv/data/app/db",
+		"path":          "kv/data/app/db",
+		"data_json":     `{"password":"hunter2"}`,
+		"data.%":        "1",
+		"data.password": "hunter2",
+	}
+	secret.Item = map[string]interface{}{
+		"path":      "kv/data/app/db",
+		"data_json": `{"password":"hunter2"}`,
+	}
+	g := &ServiceGenerator{secretsAsVariables: true}
+	g.Resources = []terraformutils.Resource{secret}
+	if err := g.PostConvertHook(); err != nil {
+		t.Fatal(err)
+	}
+
+	secret = g.Resources[0]
+	variableName := secret.ResourceName + "_data"
+	if dataJSON := secret.Item["data_json"]; dataJSON != "${jsonencode(var."+variableName+")}" {
+		t.Errorf("data_json isn't read from a variable: %v", dataJSON)
+	}
+	if variable := secret.Variables[variableName]; variable == nil || variable["sensitive"] != true {
+		t.Errorf("missing sensitive variable %s in %v", variableName, secret.Variables)
+	}
+
+	impliedType := cty.Object(map[string]cty.Type{
+		"id":        cty.String,
+		"path":      cty.String,
+		"data_json": cty.String,
+		"data":      cty.Map(cty.String),
+	})
+	state, err := terraformutils.PrintTfStateV4(g.Resources, "registry.terraform.io/hashicorp/vault", map[string]cty.Type{"vault_kv_secret_v2": impliedType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(state), "hunter2") {
+		t.Errorf("secret data written to the state:\n%s", state)
+	}
+	if !strings.Contains(string(state), `"path": "kv/data/app/db"`) {
+		t.Errorf("state is missing the secret path:\n%s", state)
+	}
+}
+
+func resourceTypesAndIDs(resources []terraformutils.Resource) []string {
+	ids := []string{}
+	for _, r := range resources {
+		ids = append(ids, r.InstanceInfo.Type+" "+r.InstanceState.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -39,6 +39,10 @@ func (p *VultrProvider) GetName() string {
 	return "vultr"
 }
 
+func (p *VultrProvider) GetSource() string {
+	return "vultr/vultr"
+}
+
 func (p *VultrProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
@@ -51,6 +51,10 @@ func (p *XenorchestraProvider) GetName() string {
 	return "xenorchestra"
 }
 
+func (p *XenorchestraProvider) GetSource() string {
+	return "vatesfr/xenorchestra"
+}
+
 func (p *XenorchestraProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{
 		"provider": map[string]interface{}{
@@ -58,6 +58,10 @@ func (p *YandexProvider) GetName() string {
 	return "yandex"
 }
 
+func (p *YandexProvider) GetSource() string {
+	return "yandex-cloud/yandex"
+}
+
 func (p *YandexProvider) GetProviderData(arg ...string) map[string]interface{} {
 	return map[string]interface{}{}
 }
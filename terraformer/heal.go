@@ -0,0 +1,264 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+const (
+	// DefaultHealIterations bounds the rewrites of Heal
+	DefaultHealIterations = 3
+
+	// HealRemove leaves the attribute out of the configuration, terraform keeps the state value
+	HealRemove = "remove"
+	// HealIgnoreChanges adds the attribute to lifecycle ignore_changes
+	HealIgnoreChanges = "ignore_changes"
+)
+
+// HealOptions configures Heal
+type HealOptions struct {
+	VerifyOptions
+	// MaxIterations bounds how many times the output is fixed and rewritten, DefaultHealIterations by default
+	MaxIterations int
+}
+
+// Healing is the outcome of Heal
+type Healing struct {
+	// Iterations counts the rewrites of the output
+	Iterations int `json:"iterations"`
+	// Fixes are the attributes changed in the output, by directory and address
+	Fixes []HealFix `json:"fixes"`
+	// Rules generalize Fixes to resource types, so later imports can apply them
+	Rules terraformutils.Rules `json:"rules"`
+	// Verification is the last plan of every directory, diffs left are the ones Heal can't fix
+	Verification *Verification `json:"verification"`
+}
+
+// HealFix is an attribute Heal removed or added to ignore_changes
+type HealFix struct {
+	Path      string `json:"path"`
+	Address   string `json:"address"`
+	Attribute string `json:"attribute"`
+	Action    string `json:"action"`
+}
+
+// healTarget is an attribute of a resource that differs in a plan
+type healTarget struct {
+	dir       string
+	address   string
+	attribute string
+}
+
+// healer keeps the fixes applied to the resources of a result between iterations
+type healer struct {
+	resources map[string]*terraformutils.Resource
+	// removed holds the values of removed attributes, restored when removing isn't enough
+	removed map[healTarget]interface{}
+	ignored map[healTarget]bool
+	// lastRemoved are the attributes removed by the last iteration, by directory
+	lastRemoved map[string][]healTarget
+}
+
+// Heal fixes the perpetual diffs of the output written by Write: it plans the output with
+// Verify, leaves attributes that differ out of the configuration so terraform keeps their state
+// value, adds the attributes that still differ, or that make the directory invalid once removed,
+// to lifecycle ignore_changes and rewrites the output with options, until the plan is clean or
+// MaxIterations is reached. options.Writer must write files to their paths on disk.
+func Heal(ctx context.Context, generator terraformutils.ProviderGenerator, result *Result, options Options, healOptions HealOptions) (*Healing, error) {
+	options = options.withDefaults()
+	if options.Stream || options.Writer == nil {
+		return nil, errors.New("healing requires the resources of the import and a writer to disk")
+	}
+	if healOptions.MaxIterations <= 0 {
+		healOptions.MaxIterations = DefaultHealIterations
+	}
+	paths := make([]string, 0, len(result.Files))
+	for path := range result.Files {
+		paths = append(paths, path)
+	}
+	pending := StateDirs(paths)
+	if len(pending) == 0 {
+		return nil, errors.New("healing needs local state files, nothing to verify")
+	}
+	h := newHealer(generator, result, options)
+	planned := map[string]DirectoryVerification{}
+	healing := &Healing{Fixes: []HealFix{}}
+	for {
+		verification, err := Verify(ctx, generator.GetName(), pending, healOptions.VerifyOptions)
+		if err != nil {
+			return nil, err
+		}
+		targets := map[string][]healTarget{}
+		for _, directory := range verification.Directories {
+			previous, wasPlanned := planned[directory.Path]
+			planned[directory.Path] = directory
+			if directory.Error != "" {
+				// removing a required attribute makes the configuration invalid
+				if wasPlanned && previous.Error == "" && len(h.lastRemoved[directory.Path]) > 0 {
+					targets[directory.Path] = h.lastRemoved[directory.Path]
+				}
+				continue
+			}
+			for _, diff := range directory.Diffs {
+				for _, attribute := range diff.Attributes {
+					if attribute == "id" {
+						// the id is unknown because of the replacement, the other attributes cause it
+						continue
+					}
+					targets[directory.Path] = append(targets[directory.Path], healTarget{dir: directory.Path, address: diff.Address, attribute: attribute})
+				}
+			}
+		}
+		pending = pending[:0]
+		for dir := range targets {
+			pending = append(pending, dir)
+		}
+		sort.Strings(pending)
+		if len(pending) == 0 || healing.Iterations == healOptions.MaxIterations {
+			break
+		}
+		fixed := h.fix(pending, targets)
+		if len(fixed) == 0 {
+			break
+		}
+		pending = fixed
+		healing.Iterations++
+		log.Printf("%s healing %d directories, iteration %d\n", generator.GetName(), len(pending), healing.Iterations)
+		if err := Write(ctx, generator, result, options); err != nil {
+			return nil, err
+		}
+	}
+
+	healing.Verification = &Verification{Directories: []DirectoryVerification{}}
+	for _, dir := range sortedDirs(planned) {
+		healing.Verification.Directories = append(healing.Verification.Directories, planned[dir])
+	}
+	healing.Fixes, healing.Rules = h.fixes()
+	return healing, nil
+}
+
+func newHealer(generator terraformutils.ProviderGenerator, result *Result, options Options) *healer {
+	h := &healer{
+		resources:   map[string]*terraformutils.Resource{},
+		removed:     map[healTarget]interface{}{},
+		ignored:     map[healTarget]bool{},
+		lastRemoved: map[string][]healTarget{},
+	}
+	isServicePath := strings.Contains(options.PathPattern, "{service}")
+	for service, resources := range result.Resources {
+		if !isServicePath {
+			service = ""
+		}
+		dir := filepath.Clean(Path(options.PathPattern, generator.GetName(), service, options.PathOutput))
+		for i := range resources {
+			resource := &resources[i]
+			h.resources[dir+"|"+resource.InstanceInfo.Type+"."+resource.ResourceName] = resource
+		}
+	}
+	return h
+}
+
+// fix changes the resources of targets and returns the directories changed
+func (h *healer) fix(dirs []string, targets map[string][]healTarget) []string {
+	fixed := []string{}
+	lastRemoved := map[string][]healTarget{}
+	for _, dir := range dirs {
+		changed := false
+		for _, target := range targets[dir] {
+			resource := h.resources[target.dir+"|"+target.address]
+			if resource == nil || h.ignored[target] {
+				log.Printf("WARN: can't heal %s of %s in %s\n", target.attribute, target.address, target.dir)
+				continue
+			}
+			if value, removed := h.removed[target]; removed {
+				// the attribute still differs, or is required, once removed
+				resource.Item[target.attribute] = value
+				delete(h.removed, target)
+				h.ignoreChanges(resource, target)
+			} else if value, exist := resource.Item[target.attribute]; exist {
+				delete(resource.Item, target.attribute)
+				h.removed[target] = value
+				lastRemoved[dir] = append(lastRemoved[dir], target)
+			} else {
+				h.ignoreChanges(resource, target)
+			}
+			changed = true
+		}
+		if changed {
+			fixed = append(fixed, dir)
+		}
+	}
+	h.lastRemoved = lastRemoved
+	return fixed
+}
+
+func (h *healer) ignoreChanges(resource *terraformutils.Resource, target healTarget) {
+	if resource.Item == nil {
+		resource.Item = map[string]interface{}{}
+	}
+	terraformutils.IgnoreChanges(resource.Item, target.attribute)
+	h.ignored[target] = true
+}
+
+// fixes lists the fixes applied and the rules they make
+func (h *healer) fixes() ([]HealFix, terraformutils.Rules) {
+	fixes := []HealFix{}
+	rules := terraformutils.Rules{}
+	add := func(target healTarget, action string) {
+		fixes = append(fixes, HealFix{Path: target.dir, Address: target.address, Attribute: target.attribute, Action: action})
+		resourceType := h.resources[target.dir+"|"+target.address].InstanceInfo.Type
+		resourceRules := &terraformutils.ResourceRules{}
+		if action == HealRemove {
+			resourceRules.Ignore = []string{terraformutils.IgnoreAttributePattern(target.attribute)}
+		} else {
+			resourceRules.Lifecycle = &terraformutils.LifecycleRules{IgnoreChanges: []string{target.attribute}}
+		}
+		rules.Merge(terraformutils.Rules{resourceType: resourceRules})
+	}
+	for target := range h.removed {
+		add(target, HealRemove)
+	}
+	for target := range h.ignored {
+		add(target, HealIgnoreChanges)
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		a, b := fixes[i], fixes[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		return a.Attribute < b.Attribute
+	})
+	return fixes, rules
+}
+
+func sortedDirs(directories map[string]DirectoryVerification) []string {
+	dirs := make([]string, 0, len(directories))
+	for dir := range directories {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
@@ -0,0 +1,740 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package terraformer imports existing infrastructure as Terraform configuration and state
+// without the CLI. Import runs the same pipeline as "terraformer import", returns the imported
+// resources and generated files, and only touches the disk when Options.Writer does:
+//
+//	result, err := terraformer.Import(ctx, terraformer.AWS{Region: "eu-west-1"}, terraformer.Options{
+//		Resources: []string{"vpc", "subnet"},
+//		Connect:   true,
+//	})
+//	for path, content := range result.Files { ... }
+package terraformer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	DefaultPathPattern = "{output}/{provider}/{service}/"
+	DefaultPathOutput  = "generated"
+	DefaultState       = "local"
+	DefaultOutput      = "hcl"
+	DefaultStateFormat = terraformutils.StateFormatV4
+
+	// ConverterFlatmap converts resources from their legacy flatmap state, as imports always did
+	ConverterFlatmap = "flatmap"
+	// ConverterCty converts resources from their refreshed cty value, keeping number and bool types
+	ConverterCty = "cty"
+)
+
+// Options configures an import, zero values fall back to the CLI defaults where they exist
+type Options struct {
+	// Resources are the services to import, "*" imports every supported service
+	Resources []string
+	// Excludes are services removed from Resources
+	Excludes []string
+	// Filter uses the --filter syntax, e.g. "vpc=id1:id2" or "Name=tags.Name;Value=foo"
+	Filter []string
+	// PathPattern places files, {output}, {provider} and {service} are replaced
+	PathPattern string
+	PathOutput  string
+	// State is "local" to write terraform.tfstate files or "bucket" to upload them to Bucket
+	State  string
+	Bucket string
+	// StateFormat is terraformutils.StateFormatV4, or StateFormatV3 for terraform 0.11
+	StateFormat string
+	// Connect links resources of different services with terraform_remote_state
+	Connect bool
+	// Compact writes all resources of a service into resources.tf
+	Compact bool
+	// Output is "hcl" or "json"
+	Output string
+	NoSort bool
+	// Verbose logs provider requests and responses
+	Verbose      bool
+	RetryCount   int
+	RetrySleepMs int
+	// Timeout bounds listing and refreshing resources, zero means no timeout
+	Timeout time.Duration
+	// ServiceTimeout bounds listing the resources of each service, a service that times out
+	// is reported in Result.Errors and the other services are imported anyway
+	ServiceTimeout time.Duration
+	// ProviderVersion is a version constraint on the provider binary used
+	ProviderVersion string
+	// Writer receives generated files, nothing is written to disk when it is nil
+	Writer terraformoutput.Writer
+	// Stream imports and writes services one at a time to bound memory, see Stream
+	Stream bool
+	// Converter is ConverterFlatmap or ConverterCty, flatmap by default since PostConvertHooks
+	// expect string values
+	Converter string
+	// Plugin serves the provider in process instead of starting its plugin, e.g. a fake in tests
+	Plugin providers.Interface
+	// Rules override the configuration of resource types, they apply to every provider
+	Rules terraformutils.Rules
+	// StripDefaults removes attributes equal to the defaults the provider plans for each resource
+	StripDefaults bool
+	// DefaultTags moves the tags common to the resources of a directory to the provider
+	// configuration, e.g. default_tags of aws, for providers implementing ProviderWithDefaultTags
+	DefaultTags bool
+	// Parameterize replaces literals of the environment, e.g. account IDs and regions, with
+	// variables and data sources, for providers implementing ProviderWithParameters
+	Parameterize bool
+	// DataSources links the values Connect can't link, targets that aren't imported, to data
+	// sources looking them up, for providers implementing ProviderWithDataSources
+	DataSources bool
+	// Naming is a template naming resources instead of their generators, e.g.
+	// "{type}_{tags.Name|name|id}", see terraformutils.Naming. The resources renamed since the
+	// local state of a previous import are moved by a moved.tf file.
+	Naming string
+}
+
+func (o Options) withDefaults() Options {
+	if o.PathPattern == "" {
+		o.PathPattern = DefaultPathPattern
+	}
+	if o.PathOutput == "" {
+		o.PathOutput = DefaultPathOutput
+	}
+	if o.State == "" {
+		o.State = DefaultState
+	}
+	if o.Output == "" {
+		o.Output = DefaultOutput
+	}
+	if o.StateFormat == "" {
+		o.StateFormat = DefaultStateFormat
+	}
+	if o.Converter == "" {
+		o.Converter = ConverterFlatmap
+	}
+	return o
+}
+
+// Result is the outcome of an import
+type Result struct {
+	// Provider is the terraform provider name, e.g. "aws"
+	Provider string
+	// Resources holds the imported resources by service
+	Resources map[string][]terraformutils.Resource
+	// Errors holds the services that failed to import, the other services are imported anyway
+	Errors map[string]error
+	// Files holds the content of every generated file by path
+	Files map[string][]byte
+	// CrashedResources lists the resources, as type.name, skipped because reading them crashed the provider
+	CrashedResources []string
+	// ImpliedTypes are the object types of the imported resource types in the provider schema,
+	// state v4 attributes are typed with them
+	ImpliedTypes map[string]cty.Type `json:"-"`
+}
+
+// Import lists, refreshes and renders the resources of provider
+func Import(ctx context.Context, provider Provider, options Options) (*Result, error) {
+	generator, args := provider.ProviderGenerator()
+	return ImportGenerator(ctx, generator, args, options)
+}
+
+// ImportGenerator is Import for a provider generator and its positional args, as used by the CLI
+func ImportGenerator(ctx context.Context, generator terraformutils.ProviderGenerator, args []string, options Options) (*Result, error) {
+	if options.Stream {
+		return Stream(ctx, generator, args, options)
+	}
+	result, err := Refresh(ctx, generator, args, options)
+	if err != nil {
+		return result, err
+	}
+	return result, Write(ctx, generator, result, options)
+}
+
+// Refresh lists and refreshes the resources of generator without rendering them
+func Refresh(ctx context.Context, generator terraformutils.ProviderGenerator, args []string, options Options) (*Result, error) {
+	options = options.withDefaults()
+	ctx, cancel := withTimeout(ctx, options.Timeout)
+	defer cancel()
+	result, providerWrapper, err := startImport(ctx, generator, args, options)
+	if err != nil {
+		return nil, err
+	}
+	defer providerWrapper.Kill()
+
+	services := Services(generator, options.Resources, options.Excludes)
+	if err := refreshServices(ctx, generator, args, services, options, providerWrapper, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// startImport initializes generator and starts its provider plugin
+func startImport(ctx context.Context, generator terraformutils.ProviderGenerator, args []string, options Options) (*Result, *providerwrapper.ProviderWrapper, error) {
+	if options.Converter != ConverterFlatmap && options.Converter != ConverterCty {
+		return nil, nil, fmt.Errorf("unknown converter %q, expected %s or %s", options.Converter, ConverterFlatmap, ConverterCty)
+	}
+	if options.Naming != "" {
+		if _, err := terraformutils.ParseNaming(options.Naming); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := generator.Init(args); err != nil {
+		return nil, nil, err
+	}
+	result := &Result{
+		Provider:     generator.GetName(),
+		Resources:    map[string][]terraformutils.Resource{},
+		Errors:       map[string]error{},
+		Files:        map[string][]byte{},
+		ImpliedTypes: map[string]cty.Type{},
+	}
+	SetProviderRequirement(generator, options.ProviderVersion)
+	providerWrapper, err := startProvider(ctx, generator, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, providerWrapper, nil
+}
+
+// startProvider starts the provider plugin of an initialized generator, or wraps options.Plugin
+func startProvider(ctx context.Context, generator terraformutils.ProviderGenerator, options Options) (*providerwrapper.ProviderWrapper, error) {
+	retryOptions := map[string]int{"retryCount": options.RetryCount, "retrySleepMs": options.RetrySleepMs}
+	var providerWrapper *providerwrapper.ProviderWrapper
+	if options.Plugin != nil {
+		providerWrapper = providerwrapper.NewProviderWrapperWithProvider(generator.GetName(), options.Plugin, options.Verbose, retryOptions)
+	} else {
+		var err error
+		providerWrapper, err = providerwrapper.NewProviderWrapper(generator.GetName(), generator.GetConfig(), options.Verbose, retryOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	providerWrapper.SetContext(ctx)
+	return providerWrapper, nil
+}
+
+// LoadImpliedTypes sets result.ImpliedTypes from the provider schema, for results that weren't
+// refreshed in this process, e.g. read from a plan file. generator must be initialized.
+func LoadImpliedTypes(ctx context.Context, generator terraformutils.ProviderGenerator, result *Result, options Options) error {
+	options = options.withDefaults()
+	SetProviderRequirement(generator, options.ProviderVersion)
+	providerWrapper, err := startProvider(ctx, generator, options)
+	if err != nil {
+		return err
+	}
+	defer providerWrapper.Kill()
+	if result.ImpliedTypes == nil {
+		result.ImpliedTypes = map[string]cty.Type{}
+	}
+	for _, resources := range result.Resources {
+		addImpliedTypes(result, providerWrapper, resources)
+	}
+	return nil
+}
+
+// addImpliedTypes adds the implied types of the resource types of resources known to the provider schema
+func addImpliedTypes(result *Result, providerWrapper *providerwrapper.ProviderWrapper, resources []terraformutils.Resource) {
+	for _, resource := range resources {
+		if resourceSchema, exist := providerWrapper.GetSchema().ResourceTypes[resource.InstanceInfo.Type]; exist {
+			result.ImpliedTypes[resource.InstanceInfo.Type] = resourceSchema.Block.ImpliedType()
+		}
+	}
+}
+
+// refreshServices lists, refreshes and converts the resources of services into result
+func refreshServices(ctx context.Context, generator terraformutils.ProviderGenerator, args []string, services []string,
+	options Options, providerWrapper *providerwrapper.ProviderWrapper, result *Result) error {
+	providerMapping := terraformutils.NewProvidersMapping(generator)
+	var failedServices []string
+	for _, service := range services {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		serviceProvider := providerMapping.AddServiceToProvider(service)
+		if err := serviceProvider.Init(args); err != nil {
+			return err
+		}
+		if err := initServiceResources(ctx, service, serviceProvider, options, providerWrapper); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			result.Errors[service] = err
+			failedServices = append(failedServices, service)
+		}
+	}
+	// remove providers that failed to init their service
+	providerMapping.RemoveServices(failedServices)
+	providerMapping.ProcessResources(false)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := terraformutils.RefreshResourcesByProvider(ctx, providerMapping, providerWrapper, options.Converter == ConverterCty); err != nil {
+		return err
+	}
+	result.CrashedResources = providerWrapper.CrashedResources()
+	providerMapping.ApplyRules(options.Rules)
+	if options.Converter == ConverterCty {
+		providerMapping.ConvertValues(providerWrapper)
+	} else {
+		providerMapping.ConvertTFStates(providerWrapper)
+	}
+	// change structs with additional data for each resource
+	providerMapping.CleanupProviders()
+	if options.StripDefaults {
+		providerMapping.StripDefaults(providerWrapper)
+	}
+
+	var naming *terraformutils.Naming
+	if options.Naming != "" {
+		var err error
+		if naming, err = terraformutils.ParseNaming(options.Naming); err != nil {
+			return err
+		}
+	}
+	for service, resources := range providerMapping.GetResourcesByService() {
+		if naming != nil {
+			terraformutils.RenameResources(resources, naming)
+		}
+		result.Resources[service] = append(result.Resources[service], resources...)
+		addImpliedTypes(result, providerWrapper, resources)
+	}
+	return nil
+}
+
+// Services expands "*", removes duplicates and excluded services
+func Services(generator terraformutils.ProviderGenerator, resources, excludes []string) []string {
+	if terraformerstring.ContainsString(resources, "*") {
+		log.Println("Attempting an import of ALL resources in " + generator.GetName())
+		resources = SupportedServices(generator)
+	}
+	seen := map[string]struct{}{}
+	services := []string{}
+	for _, r := range resources {
+		if _, ok := seen[r]; ok {
+			continue
+		}
+		seen[r] = struct{}{}
+		if terraformerstring.ContainsString(excludes, r) {
+			log.Println("Excluding resource " + r)
+			continue
+		}
+		services = append(services, r)
+	}
+	return services
+}
+
+// SupportedServices lists the services of generator in alphabetical order
+func SupportedServices(generator terraformutils.ProviderGenerator) []string {
+	var services []string
+	for k := range generator.GetSupportedService() {
+		services = append(services, k)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// SetProviderRequirement restricts the provider binary to the provider source and version constraint
+func SetProviderRequirement(generator terraformutils.ProviderGenerator, providerVersion string) {
+	requirement := providerwrapper.ProviderRequirement{Version: providerVersion}
+	if providerWithSource, ok := generator.(terraformutils.ProviderWithSource); ok {
+		requirement.Source = providerWithSource.GetSource()
+	}
+	providerwrapper.SetProviderRequirement(generator.GetName(), requirement)
+}
+
+func initServiceResources(ctx context.Context, service string, provider terraformutils.ProviderGenerator,
+	options Options, providerWrapper *providerwrapper.ProviderWrapper) error {
+	log.Println(provider.GetName() + " importing... " + service)
+	err := provider.InitService(service, options.Verbose)
+	if err != nil {
+		log.Printf("%s error importing %s, err: %s\n", provider.GetName(), service, err)
+		return err
+	}
+	provider.GetService().ParseFilters(options.Filter)
+	err = initResources(ctx, provider.GetService(), options.ServiceTimeout)
+	if err != nil {
+		log.Printf("%s error initializing resources in service %s, err: %s\n", provider.GetName(), service, err)
+		return err
+	}
+
+	if options.Converter != ConverterCty {
+		// the cty converter drops read-only attributes from the schema itself
+		provider.GetService().PopulateIgnoreKeys(providerWrapper)
+	}
+	provider.GetService().InitialCleanup()
+	log.Println(provider.GetName() + " done importing " + service)
+
+	return nil
+}
+
+// initResources lists the resources of service within timeout
+func initResources(ctx context.Context, service terraformutils.ServiceGenerator, timeout time.Duration) error {
+	serviceCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		serviceCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	service.SetContext(serviceCtx)
+	// the service keeps the import context for the API calls made after listing
+	defer service.SetContext(ctx)
+	err := service.InitResources()
+	if serviceCtx.Err() != nil && ctx.Err() == nil {
+		return fmt.Errorf("timed out after %s: %w", timeout, serviceCtx.Err())
+	}
+	return err
+}
+
+// Write connects result.Resources and renders them into result.Files and options.Writer,
+// generator must be initialized with the services of result.Resources
+func Write(ctx context.Context, generator terraformutils.ProviderGenerator, result *Result, options Options) error {
+	options = options.withDefaults()
+	SetProviderRequirement(generator, options.ProviderVersion)
+	if result.Files == nil {
+		result.Files = map[string][]byte{}
+	}
+	recorder := &recordingWriter{files: result.Files}
+	var writer terraformoutput.Writer = recorder
+	if options.Writer != nil {
+		writer = terraformoutput.MultiWriter(recorder, options.Writer)
+	}
+
+	parameters, err := environmentParameters(ctx, generator, options)
+	if err != nil {
+		return err
+	}
+	importedResource := result.Resources
+	isServicePath := strings.Contains(options.PathPattern, "{service}")
+	if options.Connect {
+		log.Println(generator.GetName() + " Connecting.... ")
+		importedResource = terraformutils.ConnectServices(importedResource, isServicePath, generator.GetResourceConnections())
+		if dataSources := connectionDataSources(generator, options); dataSources != nil {
+			for serviceName, resources := range importedResource {
+				terraformutils.ConnectDataSources(serviceName, resources, generator.GetResourceConnections(), dataSources)
+			}
+		}
+	}
+
+	if !isServicePath {
+		var compactedResources []terraformutils.Resource
+		for _, resources := range importedResource {
+			compactedResources = append(compactedResources, resources...)
+		}
+		return writeService(writer, generator, "", options, compactedResources, serviceSet(importedResource), parameters, result.ImpliedTypes)
+	}
+	for serviceName, resources := range importedResource {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writeService(writer, generator, serviceName, options, resources, serviceSet(importedResource), parameters, result.ImpliedTypes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordingWriter keeps every file in the result, paths are cleaned from the double slashes of path patterns
+type recordingWriter struct {
+	mutex sync.Mutex
+	files map[string][]byte
+}
+
+func (w *recordingWriter) WriteFile(path string, data []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.files[pathpkg.Clean(path)] = data
+	return nil
+}
+
+func serviceSet(importedResource map[string][]terraformutils.Resource) map[string]bool {
+	services := map[string]bool{}
+	for service := range importedResource {
+		services[service] = true
+	}
+	return services
+}
+
+// environmentParameters are the literals replaced when options.Parameterize is set
+func environmentParameters(ctx context.Context, generator terraformutils.ProviderGenerator, options Options) ([]terraformutils.Parameter, error) {
+	if !options.Parameterize {
+		return nil, nil
+	}
+	providerWithParameters, ok := generator.(terraformutils.ProviderWithParameters)
+	if !ok {
+		log.Printf("WARN: %s doesn't support parameters\n", generator.GetName())
+		return nil, nil
+	}
+	return providerWithParameters.GetParameters(ctx)
+}
+
+// connectionDataSources are the data sources of connected services when options.DataSources is set
+func connectionDataSources(generator terraformutils.ProviderGenerator, options Options) map[string]terraformutils.DataSource {
+	if !options.DataSources {
+		return nil
+	}
+	providerWithDataSources, ok := generator.(terraformutils.ProviderWithDataSources)
+	if !ok {
+		log.Printf("WARN: %s doesn't support data sources\n", generator.GetName())
+		return nil
+	}
+	return providerWithDataSources.GetDataSources()
+}
+
+// writeService renders resources of serviceName, importedServices are the services it can link to,
+// parameters the literals replaced by variables and data sources and impliedTypes type the state
+func writeService(writer terraformoutput.Writer, provider terraformutils.ProviderGenerator, serviceName string, options Options, resources []terraformutils.Resource, importedServices map[string]bool, parameters []terraformutils.Parameter, impliedTypes map[string]cty.Type) error {
+	log.Println(provider.GetName() + " save " + serviceName)
+	// Print HCL files for Resources
+	path := Path(options.PathPattern, provider.GetName(), serviceName, options.PathOutput)
+	providerData := provider.GetProviderData()
+	providerMaps := map[string]struct{}{}
+	if providerWithDefaultTags, ok := provider.(terraformutils.ProviderWithDefaultTags); ok && options.DefaultTags {
+		defaultTags := providerWithDefaultTags.GetDefaultTags()
+		var tags map[string]interface{}
+		resources, tags = terraformutils.LiftDefaultTags(resources, defaultTags)
+		providerMaps = terraformutils.AddDefaultTags(providerData, provider.GetName(), defaultTags, tags)
+	} else if options.DefaultTags {
+		log.Printf("WARN: %s doesn't support default tags\n", provider.GetName())
+	}
+	var usedParameters []terraformutils.Parameter
+	if len(parameters) > 0 {
+		resources, usedParameters = terraformutils.Parameterize(resources, parameters)
+		usedParameters = append(usedParameters, terraformutils.ParameterizeProviderData(providerData, parameters)...)
+	}
+	err := terraformoutput.WriteHclFilesWithProviderData(writer, resources, provider, providerData, providerMaps, path, serviceName, options.Compact, options.Output, !options.NoSort)
+	if err != nil {
+		return err
+	}
+	tfStateFile, err := printState(provider, resources, options.StateFormat, impliedTypes)
+	if err != nil {
+		return err
+	}
+	if options.Naming != "" && options.State != "bucket" && options.Writer != nil {
+		if err := writeMoves(writer, path, options, resources); err != nil {
+			return err
+		}
+	}
+	// print or upload State file
+	if options.State == "bucket" {
+		log.Println(provider.GetName() + " upload tfstate to  bucket " + options.Bucket)
+		bucket := terraformoutput.BucketState{
+			Name: options.Bucket,
+		}
+		if err := bucket.BucketUpload(path, tfStateFile); err != nil {
+			return err
+		}
+		// create Bucket file
+		if bucketStateDataFile, err := terraformutils.Print(bucket.BucketGetTfData(path), map[string]struct{}{}, options.Output, !options.NoSort); err == nil {
+			if err := writer.WriteFile(path+"/bucket.tf", bucketStateDataFile); err != nil {
+				return err
+			}
+		}
+	} else {
+		if serviceName == "" {
+			log.Println(provider.GetName() + " save tfstate")
+		} else {
+			log.Println(provider.GetName() + " save tfstate for " + serviceName)
+		}
+		if err := writer.WriteFile(path+"/terraform.tfstate", tfStateFile); err != nil {
+			return err
+		}
+	}
+	// Print hcl variables.tf
+	variables := map[string]map[string]map[string]interface{}{}
+	if serviceName != "" {
+		if options.Connect && len(provider.GetResourceConnections()[serviceName]) > 0 {
+			variables["data"] = map[string]map[string]interface{}{}
+			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
+			if options.State == "bucket" {
+				bucket := terraformoutput.BucketState{
+					Name: options.Bucket,
+				}
+				for k := range provider.GetResourceConnections()[serviceName] {
+					if !importedServices[k] {
+						continue
+					}
+					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
+						"backend": "gcs",
+						"config":  bucket.BucketGetTfData(strings.ReplaceAll(path, serviceName, k)),
+					}
+				}
+			} else {
+				for k := range provider.GetResourceConnections()[serviceName] {
+					if !importedServices[k] {
+						continue
+					}
+					variables["data"]["terraform_remote_state"][k] = map[string]interface{}{
+						"backend": "local",
+						"config": map[string]interface{}{
+							"path": strings.Repeat("../", strings.Count(path, "/")) + strings.ReplaceAll(path, serviceName, k) + "terraform.tfstate",
+						},
+					}
+				}
+			}
+			if len(variables["data"]["terraform_remote_state"]) == 0 {
+				delete(variables, "data")
+			}
+		}
+	} else {
+		if options.Connect {
+			variables["data"] = map[string]map[string]interface{}{}
+			variables["data"]["terraform_remote_state"] = map[string]interface{}{}
+			if options.State == "bucket" {
+				bucket := terraformoutput.BucketState{
+					Name: options.Bucket,
+				}
+				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
+					"backend": "gcs",
+					"config":  bucket.BucketGetTfData(path),
+				}
+			} else {
+				variables["data"]["terraform_remote_state"]["local"] = map[string]interface{}{
+					"backend": "local",
+					"config": map[string]interface{}{
+						"path": "terraform.tfstate",
+					},
+				}
+			}
+		}
+	}
+	// input variables declared by resources, e.g. redacted secrets
+	for _, resource := range resources {
+		for name, variable := range resource.Variables {
+			if variables["variable"] == nil {
+				variables["variable"] = map[string]map[string]interface{}{}
+			}
+			variables["variable"][name] = variable
+		}
+	}
+	// data sources looking up the targets of connections that aren't imported
+	for _, resource := range resources {
+		for dataSourceType, dataSources := range resource.DataSources {
+			if variables["data"] == nil {
+				variables["data"] = map[string]map[string]interface{}{}
+			}
+			if variables["data"][dataSourceType] == nil {
+				variables["data"][dataSourceType] = map[string]interface{}{}
+			}
+			for name, config := range dataSources {
+				variables["data"][dataSourceType][name] = config
+			}
+		}
+	}
+	terraformutils.AddParameterDeclarations(variables, usedParameters)
+	// create variables file
+	if len(variables) > 0 {
+		variablesFile, err := terraformutils.Print(variables, map[string]struct{}{"config": {}}, options.Output, !options.NoSort)
+		if err != nil {
+			return err
+		}
+		if err := writer.WriteFile(path+"/variables."+terraformoutput.GetFileExtension(options.Output), variablesFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMoves moves the resources named differently in the state of a previous import at path,
+// read from disk as options.Writer writes there
+func writeMoves(writer terraformoutput.Writer, path string, options Options, resources []terraformutils.Resource) error {
+	previousState, err := os.ReadFile(path + "/terraform.tfstate")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	previousNames, err := terraformutils.StateNames(previousState)
+	if err != nil {
+		log.Printf("WARN: can't read the names of %s/terraform.tfstate: %s\n", path, err)
+		return nil
+	}
+	moves := terraformutils.Moves(previousNames, resources)
+	if len(moves) == 0 {
+		return nil
+	}
+	log.Printf("%s moving %d renamed resources\n", path, len(moves))
+	movedFile, err := terraformutils.PrintMoves(moves, options.Output)
+	if err != nil {
+		return err
+	}
+	return writer.WriteFile(path+"/moved."+terraformoutput.GetFileExtension(options.Output), movedFile)
+}
+
+// printState renders resources in stateFormat, v4 states address resources to the provider used for import
+func printState(provider terraformutils.ProviderGenerator, resources []terraformutils.Resource, stateFormat string, impliedTypes map[string]cty.Type) ([]byte, error) {
+	switch stateFormat {
+	case terraformutils.StateFormatV3:
+		return terraformutils.PrintTfState(resources)
+	case terraformutils.StateFormatV4:
+		address := "registry.terraform.io/hashicorp/" + provider.GetName()
+		if lock, err := providerwrapper.GetProviderLock(provider.GetName()); err == nil {
+			address = lock.Address
+		} else if providerWithSource, ok := provider.(terraformutils.ProviderWithSource); ok && providerWithSource.GetSource() != "" {
+			address = "registry.terraform.io/" + providerWithSource.GetSource()
+		}
+		return terraformutils.PrintTfStateV4(resources, address, impliedTypes)
+	}
+	return nil, fmt.Errorf("unknown state format %q, expected %s or %s", stateFormat, terraformutils.StateFormatV3, terraformutils.StateFormatV4)
+}
+
+// Path replaces the placeholders of pathPattern
+func Path(pathPattern, providerName, serviceName, output string) string {
+	return strings.NewReplacer(
+		"{provider}", providerName,
+		"{service}", serviceName,
+		"{output}", output,
+	).Replace(pathPattern)
+}
+
+// Err joins the errors of failed services, nil when every service was imported
+func (r *Result) Err() error {
+	if r == nil || len(r.Errors) == 0 {
+		return nil
+	}
+	services := make([]string, 0, len(r.Errors))
+	for service := range r.Errors {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	messages := make([]string, 0, len(services))
+	for _, service := range services {
+		messages = append(messages, fmt.Sprintf("%s: %s", service, r.Errors[service]))
+	}
+	return fmt.Errorf("%s services failed to import: %s", r.Provider, strings.Join(messages, "; "))
+}
@@ -0,0 +1,214 @@
+package terraformer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type thingProvider struct {
+	terraformutils.Provider
+}
+
+func (p *thingProvider) Init(args []string) error { return nil }
+
+func (p *thingProvider) GetName() string { return "v6test" }
+
+func (p *thingProvider) GetConfig() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{"endpoint": cty.StringVal("https://example.com")})
+}
+
+func (p *thingProvider) InitService(serviceName string, verbose bool) error {
+	p.Service = p.GetSupportedService()[serviceName]
+	p.Service.SetName(serviceName)
+	p.Service.SetProviderName(p.GetName())
+	return nil
+}
+
+func (p *thingProvider) GetSupportedService() map[string]terraformutils.ServiceGenerator {
+	return map[string]terraformutils.ServiceGenerator{
+		"things": &thingService{},
+		"broken": &thingService{err: errors.New("no access")},
+		"slow":   &slowService{},
+	}
+}
+
+func (p *thingProvider) GetProviderData(arg ...string) map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (p *thingProvider) GetResourceConnections() map[string]map[string][]string {
+	return map[string]map[string][]string{}
+}
+
+type thingService struct {
+	terraformutils.Service
+	err error
+}
+
+func (s *thingService) InitResources() error {
+	if s.err != nil {
+		return s.err
+	}
+	s.Resources = []terraformutils.Resource{
+		terraformutils.NewSimpleResource("a", "a", "v6test_thing", "v6test", []string{}),
+	}
+	return nil
+}
+
+// slowService lists resources until its context is done
+type slowService struct {
+	terraformutils.Service
+}
+
+func (s *slowService) InitResources() error {
+	<-s.Context().Done()
+	return s.Context().Err()
+}
+
+// buildThingProvider installs the providerwrapper v6 test plugin into a fake TF_DATA_DIR
+func buildThingProvider(t *testing.T) {
+	dataDir := t.TempDir()
+	pluginDir := filepath.Join(dataDir, "providers", "registry.terraform.io", "terraformer", "v6test", "0.0.1", runtime.GOOS+"_"+runtime.GOARCH)
+	build := exec.Command("go", "build", "-o", filepath.Join(pluginDir, "terraform-provider-v6test_v0.0.1"),
+		"../terraformutils/providerwrapper/testdata/terraform-provider-v6test")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		t.Fatalf("can't build test provider: %v", err)
+	}
+	t.Setenv("TF_DATA_DIR", dataDir)
+	t.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(dataDir, "terraformrc"))
+	t.Setenv("HOME", dataDir)
+}
+
+func TestImport(t *testing.T) {
+	buildThingProvider(t)
+	writer := terraformoutput.NewMemoryWriter()
+
+	result, err := Import(context.Background(), Generic{Generator: &thingProvider{}}, Options{
+		Resources:  []string{"things", "broken", "things"},
+		RetryCount: 1,
+		Writer:     writer,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Resources["things"]) != 1 {
+		t.Fatalf("expected one thing, got %v", result.Resources)
+	}
+	if result.Errors["broken"] == nil || !strings.Contains(result.Err().Error(), "broken: no access") {
+		t.Errorf("expected broken service error, got %v", result.Errors)
+	}
+	thing, ok := result.Files["generated/v6test/things/thing.tf"]
+	if !ok {
+		t.Fatalf("thing.tf not generated, files: %v", result.Files)
+	}
+	if !strings.Contains(string(thing), `name = "https://example.com/a"`) {
+		t.Errorf("unexpected thing.tf:\n%s", thing)
+	}
+	for _, name := range []string{"provider.tf", "terraform.tfstate", ".terraform.lock.hcl"} {
+		if _, ok := result.Files["generated/v6test/things/"+name]; !ok {
+			t.Errorf("%s not generated", name)
+		}
+	}
+	state := string(result.Files["generated/v6test/things/terraform.tfstate"])
+	if !strings.Contains(state, `"version": 4`) || !strings.Contains(state, `provider[\"registry.terraform.io/terraformer/v6test\"]`) {
+		t.Errorf("unexpected terraform.tfstate:\n%s", state)
+	}
+	if len(writer.Files) != len(result.Files) {
+		t.Errorf("writer received %d files, result has %d", len(writer.Files), len(result.Files))
+	}
+}
+
+func TestImportCanceled(t *testing.T) {
+	buildThingProvider(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Import(ctx, Generic{Generator: &thingProvider{}}, Options{Resources: []string{"things"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestImportServiceTimeout(t *testing.T) {
+	buildThingProvider(t)
+
+	result, err := Import(context.Background(), Generic{Generator: &thingProvider{}}, Options{
+		Resources:      []string{"slow", "things"},
+		ServiceTimeout: 100 * time.Millisecond,
+		Writer:         terraformoutput.NewMemoryWriter(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(result.Errors["slow"], context.DeadlineExceeded) {
+		t.Errorf("expected slow service to time out, got %v", result.Errors["slow"])
+	}
+	if len(result.Resources["things"]) != 1 {
+		t.Errorf("expected things to be imported, got %v", result.Resources)
+	}
+}
+
+func TestImportStream(t *testing.T) {
+	buildThingProvider(t)
+	writer := terraformoutput.NewMemoryWriter()
+
+	result, err := Import(context.Background(), Generic{Generator: &thingProvider{}}, Options{
+		Resources: []string{"broken", "things"},
+		Connect:   true,
+		Stream:    true,
+		Writer:    writer,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Errors["broken"] == nil {
+		t.Errorf("expected broken service error, got %v", result.Errors)
+	}
+	if len(result.Resources) != 0 || len(result.Files) != 0 {
+		t.Errorf("streamed import kept resources %v and files %v", result.Resources, result.Files)
+	}
+	if _, ok := writer.Files["generated/v6test/things/thing.tf"]; !ok {
+		t.Errorf("thing.tf not streamed, files: %v", writer.Files)
+	}
+}
+
+func TestConnectionOrder(t *testing.T) {
+	connections := map[string]map[string][]string{
+		"subnet":   {"vpc": {"vpc_id", "id"}},
+		"instance": {"subnet": {"subnet_id", "id"}, "sg": {"security_groups", "id"}},
+	}
+	order := connectionOrder([]string{"instance", "vpc", "subnet", "s3"}, connections)
+	expected := []string{"vpc", "subnet", "instance", "s3"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, order)
+	}
+}
+
+func TestImportCtyConverter(t *testing.T) {
+	buildThingProvider(t)
+
+	result, err := Import(context.Background(), Generic{Generator: &thingProvider{}}, Options{
+		Resources: []string{"things"},
+		Converter: ConverterCty,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	thing := string(result.Files["generated/v6test/things/thing.tf"])
+	if !strings.Contains(thing, `name = "https://example.com/a"`) || !strings.Contains(thing, "enabled = true") {
+		t.Errorf("unexpected thing.tf:\n%s", thing)
+	}
+}
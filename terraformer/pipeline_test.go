@@ -0,0 +1,306 @@
+package terraformer_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest"
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest/fakeplugin"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformoutput"
+)
+
+func TestImportFakePlugin(t *testing.T) {
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeplugin.Backend{
+		Objects: map[string]map[string]map[string]json.RawMessage{
+			"fake_network": {
+				"net-1": {
+					"name":        json.RawMessage(`"main"`),
+					"cidr":        json.RawMessage(`"10.0.0.0/16"`),
+					"description": json.RawMessage(`""`),
+					"tags":        json.RawMessage(`{"env":"prod","team":"platform"}`),
+					"urn":         json.RawMessage(`"urn:fake:net-1"`),
+					"created_at":  json.RawMessage(`"2024-01-01T00:00:00Z"`),
+					"subnet":      json.RawMessage(`[{"name":"a","cidr":"10.0.1.0/24","gateway":"10.0.1.1"},{"name":"b","cidr":"10.0.2.0/24","gateway":"10.0.2.1"}]`),
+					"route":       json.RawMessage(`[{"destination":"0.0.0.0/0","next_hop":"igw"},{"destination":"10.1.0.0/16"}]`),
+				},
+				"net-2": {
+					"name": json.RawMessage(`"edge"`),
+					"cidr": json.RawMessage(`"10.1.0.0/16"`),
+					"urn":  json.RawMessage(`"urn:fake:net-2"`),
+				},
+			},
+			"fake_instance": {
+				"i-1": {
+					"name":        json.RawMessage(`"web"`),
+					"network_id":  json.RawMessage(`"net-1"`),
+					"size":        json.RawMessage(`"small"`),
+					"zones":       json.RawMessage(`["z1","z2"]`),
+					"metadata":    json.RawMessage(`{"role":"web"}`),
+					"fingerprint": json.RawMessage(`"f1"`),
+					"status":      json.RawMessage(`"running"`),
+					"disk":        json.RawMessage(`[{"size_gb":20,"type":"ssd","device_name":"/dev/sda"}]`),
+				},
+				"i-2": {
+					"name":       json.RawMessage(`"worker"`),
+					"network_id": json.RawMessage(`"net-2"`),
+					"size":       json.RawMessage(`"large"`),
+					"status":     json.RawMessage(`"running"`),
+				},
+				"i-3": {
+					"name":       json.RawMessage(`"broken"`),
+					"network_id": json.RawMessage(`"net-1"`),
+				},
+			},
+		},
+		LatencyMs: 5,
+		// net-1 can only be read by retrying its throttled reads
+		ThrottledReads: 2,
+		// i-2 is imported instead of read, i-3 can't be refreshed and is dropped
+		FailReads:   []string{"i-2", "i-3"},
+		FailImports: []string{"net-1", "i-3"},
+	})
+
+	terraformertest.Golden(t, &terraformertest.FakeGenerator{}, args, terraformer.Options{
+		Resources:    []string{"networks", "instances"},
+		Connect:      true,
+		RetryCount:   3,
+		RetrySleepMs: 1,
+	}, "testdata/fake/golden")
+}
+
+// stubTerraform checks the plugin dir and state of each directory and shows the plan saved
+// as $PLANS/<directory name>.json, directories without a plan fail to plan
+const stubTerraform = `#!/bin/sh
+case "$1" in
+init)
+	for arg in "$@"; do
+		case "$arg" in
+		-plugin-dir=*) test -x "${arg#-plugin-dir=}"/registry.terraform.io/terraformer/fake/0.0.1/*/terraform-provider-fake_v0.0.1 || { echo "fake provider isn't mirrored" >&2; exit 1; } ;;
+		esac
+	done
+	test -n "$TF_DATA_DIR" || exit 1 ;;
+plan)
+	test -f terraform.tfstate || { echo "no state" >&2; exit 1; }
+	test -f "$PLANS/$(basename "$PWD").json" || { echo "Error: invalid configuration" >&2; exit 1; } ;;
+show)
+	cat "$PLANS/$(basename "$PWD").json" ;;
+esac
+`
+
+func TestVerify(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the terraform stub is a shell script")
+	}
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeplugin.Backend{
+		Objects: map[string]map[string]map[string]json.RawMessage{
+			"fake_network":  {"net-1": {"name": json.RawMessage(`"main"`)}},
+			"fake_instance": {"i-1": {"name": json.RawMessage(`"web"`), "network_id": json.RawMessage(`"net-1"`)}},
+		},
+	})
+	output := t.TempDir()
+	result, err := terraformer.ImportGenerator(context.Background(), &terraformertest.FakeGenerator{}, args, terraformer.Options{
+		Resources:  []string{"networks", "instances"},
+		PathOutput: output,
+		RetryCount: 1,
+		Writer:     terraformoutput.FileWriter{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := t.TempDir()
+	binary := filepath.Join(binDir, "terraform")
+	if err := os.WriteFile(binary, []byte(stubTerraform), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	plans := t.TempDir()
+	t.Setenv("PLANS", plans)
+	if err := os.WriteFile(filepath.Join(plans, "networks.json"), []byte(`{"resource_changes": [
+		{"address": "fake_network.tfer--main", "mode": "managed", "change": {"actions": ["delete", "create"],
+			"before": {"name": "main", "cidr": null}, "after": {"name": "main", "cidr": "10.0.0.0/16"}, "after_unknown": {"id": true}}},
+		{"address": "fake_network.tfer--edge", "mode": "managed", "change": {"actions": ["update"],
+			"before": {"tags": {"a": "b"}}, "after": {"tags": {}}, "after_unknown": {"tags": {}}}},
+		{"address": "fake_network.tfer--other", "mode": "managed", "change": {"actions": ["no-op"]}},
+		{"address": "data.terraform_remote_state.x", "mode": "data", "change": {"actions": ["read"]}}
+	]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := make([]string, 0, len(result.Files))
+	for path := range result.Files {
+		files = append(files, path)
+	}
+	dirs := terraformer.StateDirs(files)
+	verification, err := terraformer.Verify(context.Background(), "fake", dirs, terraformer.VerifyOptions{Binary: binary})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &terraformer.Verification{Directories: []terraformer.DirectoryVerification{
+		{Path: filepath.Join(output, "fake", "instances"), Diffs: []terraformer.ResourceDiff{},
+			Error: binary + " plan: exit status 1\nError: invalid configuration"},
+		{Path: filepath.Join(output, "fake", "networks"), Diffs: []terraformer.ResourceDiff{
+			{Address: "fake_network.tfer--main", Kind: terraformer.DiffReplace, Actions: []string{"delete", "create"}, Attributes: []string{"cidr", "id"}},
+			{Address: "fake_network.tfer--edge", Kind: terraformer.DiffUpdate, Actions: []string{"update"}, Attributes: []string{"tags"}},
+		}},
+	}}
+	if !reflect.DeepEqual(verification, expected) {
+		t.Errorf("unexpected verification\n%+v\nexpected\n%+v", verification, expected)
+	}
+	if verification.Err() == nil {
+		t.Error("verification with diffs should fail")
+	}
+}
+
+// healStubTerraform plans the configuration of networks and instances: a description differs
+// from the state until it's removed, a size differs until its changes are ignored and a
+// network_id differs but is required
+const healStubTerraform = `#!/bin/sh
+has() { grep -Eq "^[[:space:]]*$1[[:space:]]*=" *.tf; }
+ignored() { grep -Eq "ignore_changes[[:space:]]*=[[:space:]]*\[[^]]*$1" *.tf; }
+diff() { echo "{\"address\": \"$1\", \"mode\": \"managed\", \"change\": {\"actions\": [\"update\"], \"before\": {\"$2\": \"state\"}, \"after\": {\"$2\": \"config\"}, \"after_unknown\": {}}}"; }
+case "$1" in
+plan)
+	if [ "$(basename "$PWD")" = instances ] && ! has network_id; then
+		echo 'Error: Missing required argument "network_id"' >&2
+		exit 1
+	fi ;;
+show)
+	changes=""
+	add() { changes="${changes:+$changes,}$(diff "$1" "$2")"; }
+	if [ "$(basename "$PWD")" = networks ]; then
+		has description && add fake_network.tfer--main description
+	else
+		ignored size || add fake_instance.tfer--web size
+		ignored network_id || add fake_instance.tfer--web network_id
+	fi
+	echo "{\"resource_changes\": [$changes]}" ;;
+esac
+`
+
+func TestHeal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the terraform stub is a shell script")
+	}
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeplugin.Backend{
+		Objects: map[string]map[string]map[string]json.RawMessage{
+			"fake_network": {"net-1": {"name": json.RawMessage(`"main"`), "description": json.RawMessage(`"main network"`)}},
+			"fake_instance": {"i-1": {
+				"name": json.RawMessage(`"web"`), "network_id": json.RawMessage(`"net-1"`), "size": json.RawMessage(`"small"`)}},
+		},
+	})
+	output := t.TempDir()
+	options := terraformer.Options{
+		Resources:  []string{"networks", "instances"},
+		PathOutput: output,
+		RetryCount: 1,
+		Writer:     terraformoutput.FileWriter{},
+	}
+	generator := &terraformertest.FakeGenerator{}
+	result, err := terraformer.ImportGenerator(context.Background(), generator, args, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(t.TempDir(), "terraform")
+	if err := os.WriteFile(binary, []byte(healStubTerraform), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	healing, err := terraformer.Heal(context.Background(), generator, result, options, terraformer.HealOptions{
+		VerifyOptions: terraformer.VerifyOptions{Binary: binary},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := healing.Verification.Err(); err != nil {
+		t.Errorf("healed output still differs: %s", err)
+	}
+	if healing.Iterations != 2 {
+		t.Errorf("healed in %d iterations, expected 2", healing.Iterations)
+	}
+	instances := filepath.Join(output, "fake", "instances")
+	networks := filepath.Join(output, "fake", "networks")
+	expectedFixes := []terraformer.HealFix{
+		{Path: instances, Address: "fake_instance.tfer--web", Attribute: "network_id", Action: terraformer.HealIgnoreChanges},
+		{Path: instances, Address: "fake_instance.tfer--web", Attribute: "size", Action: terraformer.HealIgnoreChanges},
+		{Path: networks, Address: "fake_network.tfer--main", Attribute: "description", Action: terraformer.HealRemove},
+	}
+	if !reflect.DeepEqual(healing.Fixes, expectedFixes) {
+		t.Errorf("unexpected fixes\n%+v\nexpected\n%+v", healing.Fixes, expectedFixes)
+	}
+	expectedRules := terraformutils.Rules{
+		"fake_instance": {Lifecycle: &terraformutils.LifecycleRules{IgnoreChanges: []string{"network_id", "size"}}},
+		"fake_network":  {Ignore: []string{`^description($|\.)`}},
+	}
+	if !reflect.DeepEqual(healing.Rules, expectedRules) {
+		t.Errorf("unexpected rules\n%+v\nexpected\n%+v", healing.Rules, expectedRules)
+	}
+
+	instance, err := os.ReadFile(filepath.Join(instances, "instance.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"ignore_changes = [network_id, size]", `network_id = "net-1"`, `size       = "small"`} {
+		if !strings.Contains(string(instance), expected) {
+			t.Errorf("instance.tf doesn't contain %q:\n%s", expected, instance)
+		}
+	}
+}
+
+func TestStripDefaults(t *testing.T) {
+	terraformertest.InstallFakePlugin(t)
+	args := terraformertest.FakeArgs(t, fakeplugin.Backend{
+		Objects: map[string]map[string]map[string]json.RawMessage{
+			"fake_network": {
+				"net-1": {"name": json.RawMessage(`"main"`), "cidr": json.RawMessage(`"10.0.0.0/16"`), "tags": json.RawMessage(`{"env":"prod"}`)},
+				"net-2": {"name": json.RawMessage(`"edge"`), "cidr": json.RawMessage(`"10.1.0.0/16"`), "tags": json.RawMessage(`{"env":"dev"}`)},
+			},
+			"fake_instance": {"i-1": {
+				"name": json.RawMessage(`"web"`), "network_id": json.RawMessage(`"net-1"`), "size": json.RawMessage(`"small"`), "zones": json.RawMessage(`["z2","z1"]`)}},
+		},
+		Defaults: map[string]map[string]json.RawMessage{
+			"fake_network":  {"cidr": json.RawMessage(`"10.0.0.0/16"`), "tags": json.RawMessage(`{"env":"prod"}`)},
+			"fake_instance": {"size": json.RawMessage(`"small"`), "zones": json.RawMessage(`["z1","z2"]`)},
+		},
+	})
+	for _, converter := range []string{terraformer.ConverterFlatmap, terraformer.ConverterCty} {
+		t.Run(converter, func(t *testing.T) {
+			result, err := terraformer.Refresh(context.Background(), &terraformertest.FakeGenerator{}, args, terraformer.Options{
+				Resources:     []string{"networks", "instances"},
+				RetryCount:    1,
+				Converter:     converter,
+				StripDefaults: true,
+				Rules: terraformutils.Rules{
+					"fake_network": {Set: map[string]interface{}{"tags.owner": "platform"}},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			items := map[string]map[string]interface{}{}
+			for _, resources := range result.Resources {
+				for _, resource := range resources {
+					items[resource.InstanceState.ID] = resource.Item
+				}
+			}
+			expected := map[string]map[string]interface{}{
+				"net-1": {"name": "main", "tags": map[string]interface{}{"env": "prod", "owner": "platform"}},
+				"net-2": {"name": "edge", "cidr": "10.1.0.0/16", "tags": map[string]interface{}{"env": "dev", "owner": "platform"}},
+				"i-1":   {"name": "web", "network_id": "net-1"},
+			}
+			if !reflect.DeepEqual(items, expected) {
+				t.Errorf("unexpected items\n%#v\nexpected\n%#v", items, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,509 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformer
+
+import (
+	"strconv"
+	"strings"
+
+	alicloud_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/alicloud"
+	auth0_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/auth0"
+	awsterraformer "github.com/GoogleCloudPlatform/terraformer/providers/aws"
+	azure_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/azure"
+	azuread "github.com/GoogleCloudPlatform/terraformer/providers/azuread"
+	azuredevops "github.com/GoogleCloudPlatform/terraformer/providers/azuredevops"
+	cloudflare_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/cloudflare"
+	commercetools_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/commercetools"
+	datadog_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/datadog"
+	digitalocean_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/digitalocean"
+	equinixmetal_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/equinixmetal"
+	fastly_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/fastly"
+	gcp_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gcp"
+	github_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/github"
+	gitLab_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gitlab"
+	gmailfilter_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/gmailfilter"
+	"github.com/GoogleCloudPlatform/terraformer/providers/grafana"
+	heroku_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/heroku"
+	honeycombio_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/honeycombio"
+	ibm_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/ibm"
+	ionoscloud_terraformer "github.com/GoogleCloudPlatform/terraformer/providers/ionoscloud"
+	keycloak_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/keycloak"
+	kubernetes_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/kubernetes"
+	launchdarkly_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/launchdarkly"
+	linode_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/linode"
+	logzio_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/logzio"
+	mackerel_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/mackerel"
+	mikrotik_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/mikrotik"
+	myrasec_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/myrasec"
+	newrelic_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/newrelic"
+	ns1_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/ns1"
+	octopusdeploy_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/octopusdeploy"
+	okta_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/okta"
+	opal_terraformer "github.com/GoogleCloudPlatform/terraformer/providers/opal"
+	openstack_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/openstack"
+	opsgenie_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/opsgenie"
+	pagerduty_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/pagerduty"
+	panos_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/panos"
+	rabbitmq_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/rabbitmq"
+	tencentcloud_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/tencentcloud"
+	vault_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/vault"
+	vultr_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/vultr"
+	xenorchestra_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/xenorchestra"
+	yandex_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/yandex"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// Provider is a typed provider configuration, it builds the provider generator and its
+// positional Init args. Credentials left empty are read by the provider from its usual env vars.
+type Provider interface {
+	ProviderGenerator() (terraformutils.ProviderGenerator, []string)
+}
+
+// Generic imports with any provider generator, e.g. one implemented outside this repository
+type Generic struct {
+	Generator terraformutils.ProviderGenerator
+	Args      []string
+}
+
+func (p Generic) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return p.Generator, p.Args
+}
+
+// Defaults applied by the CLI and the Keycloak, Logzio and NewRelic configurations
+const (
+	DefaultKeycloakEndpoint      = "https://localhost:8443"
+	DefaultKeycloakRealm         = "master"
+	DefaultKeycloakClientTimeout = int64(30)
+	DefaultLogzioBaseURL         = "https://api.logz.io"
+	DefaultNewRelicRegion        = "US"
+	DefaultGoogleRegion          = "global"
+	DefaultAWSProfile            = "default"
+)
+
+// AWS imports one region, an empty Region imports with the default region of the profile
+type AWS struct {
+	Region  string
+	Profile string
+}
+
+func (p AWS) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	profile := p.Profile
+	if profile == "" {
+		profile = DefaultAWSProfile
+	}
+	return &awsterraformer.AWSProvider{}, []string{p.Region, profile}
+}
+
+// Google imports one project, or the organization hierarchy when Organization or Folders is set
+type Google struct {
+	Project string
+	// Region defaults to "global"
+	Region string
+	// ProviderType is empty for google, "beta" for google-beta
+	ProviderType string
+	Organization string
+	Folders      []string
+}
+
+func (p Google) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	region := p.Region
+	if region == "" {
+		region = DefaultGoogleRegion
+	}
+	return &gcp_terraforming.GCPProvider{}, []string{region, p.Project, p.ProviderType, p.Organization, strings.Join(p.Folders, ",")}
+}
+
+type Azure struct {
+	ResourceGroup string
+}
+
+func (p Azure) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &azure_terraforming.AzureProvider{}, []string{p.ResourceGroup}
+}
+
+type AzureAD struct{}
+
+func (p AzureAD) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &azuread.AzureADProvider{}, []string{""}
+}
+
+type AzureDevOps struct{}
+
+func (p AzureDevOps) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &azuredevops.AzureDevOpsProvider{}, []string{""}
+}
+
+type AliCloud struct {
+	Region  string
+	Profile string
+}
+
+func (p AliCloud) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &alicloud_terraforming.AliCloudProvider{}, []string{p.Region, p.Profile}
+}
+
+type IBM struct {
+	ResourceGroup string
+	Region        string
+	CIS           string
+	VPC           string
+}
+
+func (p IBM) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &ibm_terraforming.IBMProvider{}, []string{p.ResourceGroup, p.Region, p.CIS, p.VPC}
+}
+
+type DigitalOcean struct{}
+
+func (p DigitalOcean) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &digitalocean_terraforming.DigitalOceanProvider{}, []string{}
+}
+
+type EquinixMetal struct{}
+
+func (p EquinixMetal) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &equinixmetal_terraforming.EquinixMetalProvider{}, []string{}
+}
+
+type Heroku struct {
+	APIKey string
+	Team   string
+}
+
+func (p Heroku) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &heroku_terraforming.HerokuProvider{}, []string{p.APIKey, p.Team}
+}
+
+type LaunchDarkly struct{}
+
+func (p LaunchDarkly) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &launchdarkly_terraforming.LaunchDarklyProvider{}, []string{}
+}
+
+type Linode struct{}
+
+func (p Linode) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &linode_terraforming.LinodeProvider{}, []string{}
+}
+
+type OpenStack struct {
+	Region string
+}
+
+func (p OpenStack) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &openstack_terraforming.OpenStackProvider{}, []string{p.Region}
+}
+
+type TencentCloud struct {
+	Region string
+}
+
+func (p TencentCloud) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &tencentcloud_terraforming.TencentCloudProvider{}, []string{p.Region}
+}
+
+type Vultr struct{}
+
+func (p Vultr) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &vultr_terraforming.VultrProvider{}, []string{}
+}
+
+type Yandex struct {
+	FolderID string
+}
+
+func (p Yandex) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &yandex_terraforming.YandexProvider{}, []string{p.FolderID}
+}
+
+type IonosCloud struct{}
+
+func (p IonosCloud) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &ionoscloud_terraformer.IonosCloudProvider{}, []string{}
+}
+
+type Kubernetes struct {
+	Verbose bool
+}
+
+func (p Kubernetes) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &kubernetes_terraforming.KubernetesProvider{}, []string{strconv.FormatBool(p.Verbose)}
+}
+
+type OctopusDeploy struct {
+	Server string
+	APIKey string
+}
+
+func (p OctopusDeploy) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &octopusdeploy_terraforming.OctopusDeployProvider{}, []string{p.Server, p.APIKey}
+}
+
+type RabbitMQ struct {
+	Endpoint string
+	Username string
+	Password string
+}
+
+func (p RabbitMQ) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &rabbitmq_terraforming.RBTProvider{}, []string{p.Endpoint, p.Username, p.Password}
+}
+
+type Myrasec struct{}
+
+func (p Myrasec) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &myrasec_terraforming.MyrasecProvider{}, []string{}
+}
+
+type Cloudflare struct{}
+
+func (p Cloudflare) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &cloudflare_terraforming.CloudflareProvider{}, []string{}
+}
+
+type Fastly struct{}
+
+func (p Fastly) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &fastly_terraforming.FastlyProvider{}, []string{}
+}
+
+type NS1 struct{}
+
+func (p NS1) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &ns1_terraforming.Ns1Provider{}, []string{}
+}
+
+// Panos imports one VSYS, resources not callable on the device should be removed with panos.FilterCallableResources
+type Panos struct {
+	Vsys string
+}
+
+func (p Panos) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &panos_terraforming.PanosProvider{}, []string{p.Vsys}
+}
+
+type GitHub struct {
+	Owner   string
+	Token   string
+	BaseURL string
+}
+
+func (p GitHub) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &github_terraforming.GithubProvider{}, []string{p.Owner, p.Token, p.BaseURL}
+}
+
+type GitLab struct {
+	Group   string
+	Token   string
+	BaseURL string
+}
+
+func (p GitLab) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &gitLab_terraforming.GitLabProvider{}, []string{p.Group, p.Token, p.BaseURL}
+}
+
+type Datadog struct {
+	APIKey string
+	AppKey string
+	APIURL string
+	// Validate is a bool-parsable value, empty keeps the provider default
+	Validate string
+}
+
+func (p Datadog) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &datadog_terraforming.DatadogProvider{}, []string{p.APIKey, p.AppKey, p.APIURL, p.Validate}
+}
+
+type NewRelic struct {
+	APIKey    string
+	AccountID string
+	// Region defaults to "US"
+	Region string
+}
+
+func (p NewRelic) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	region := p.Region
+	if region == "" {
+		region = DefaultNewRelicRegion
+	}
+	return &newrelic_terraforming.NewRelicProvider{}, []string{p.APIKey, p.AccountID, region}
+}
+
+type Mackerel struct {
+	APIKey string
+}
+
+func (p Mackerel) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &mackerel_terraforming.MackerelProvider{}, []string{p.APIKey}
+}
+
+type Grafana struct{}
+
+func (p Grafana) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &grafana.GrafanaProvider{}, []string{}
+}
+
+type PagerDuty struct {
+	Token string
+}
+
+func (p PagerDuty) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &pagerduty_terraforming.PagerDutyProvider{}, []string{p.Token}
+}
+
+type Opsgenie struct {
+	APIKey string
+}
+
+func (p Opsgenie) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &opsgenie_terraforming.OpsgenieProvider{}, []string{p.APIKey}
+}
+
+type Honeycombio struct {
+	Datasets []string
+}
+
+func (p Honeycombio) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &honeycombio_terraforming.HoneycombProvider{}, p.Datasets
+}
+
+// Opal reads OPAL_AUTH_TOKEN and OPAL_BASE_URL
+type Opal struct{}
+
+func (p Opal) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &opal_terraformer.OpalProvider{}, []string{}
+}
+
+// Keycloak imports one realm or all realms when Target is empty
+type Keycloak struct {
+	// URL defaults to https://localhost:8443
+	URL string
+	// BasePath is "/auth" for legacy Keycloak versions
+	BasePath     string
+	ClientID     string
+	ClientSecret string
+	// Realm is the realm of the client and defaults to "master"
+	Realm string
+	// ClientTimeout is in seconds and defaults to 30
+	ClientTimeout         int64
+	CACert                string
+	TLSInsecureSkipVerify bool
+	RedHatSSO             bool
+	// Target is the realm to import
+	Target string
+}
+
+func (p Keycloak) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	url, realm, clientTimeout, caCert, target := p.URL, p.Realm, p.ClientTimeout, p.CACert, p.Target
+	if url == "" {
+		url = DefaultKeycloakEndpoint
+	}
+	if realm == "" {
+		realm = DefaultKeycloakRealm
+	}
+	if clientTimeout == 0 {
+		clientTimeout = DefaultKeycloakClientTimeout
+	}
+	if caCert == "" {
+		caCert = "-"
+	}
+	if target == "" {
+		target = "-"
+	}
+	return &keycloak_terraforming.KeycloakProvider{}, []string{url, p.BasePath, p.ClientID, p.ClientSecret, realm,
+		strconv.FormatInt(clientTimeout, 10), caCert, strconv.FormatBool(p.TLSInsecureSkipVerify), strconv.FormatBool(p.RedHatSSO), target}
+}
+
+type Logzio struct {
+	Token string
+	// BaseURL defaults to https://api.logz.io
+	BaseURL string
+}
+
+func (p Logzio) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	baseURL := p.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultLogzioBaseURL
+	}
+	return &logzio_terraforming.LogzioProvider{}, []string{p.Token, baseURL}
+}
+
+type Commercetools struct {
+	ClientID     string
+	ClientScope  string
+	ClientSecret string
+	ProjectKey   string
+	BaseURL      string
+	TokenURL     string
+}
+
+func (p Commercetools) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &commercetools_terraforming.CommercetoolsProvider{}, []string{p.ClientID, p.ClientScope, p.ClientSecret, p.ProjectKey, p.BaseURL, p.TokenURL}
+}
+
+type Mikrotik struct{}
+
+func (p Mikrotik) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &mikrotik_terraforming.MikrotikProvider{}, []string{}
+}
+
+type Xenorchestra struct{}
+
+func (p Xenorchestra) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &xenorchestra_terraforming.XenorchestraProvider{}, []string{}
+}
+
+type Gmailfilter struct {
+	// Credentials is the path to client_secret.json
+	Credentials string
+	Email       string
+}
+
+func (p Gmailfilter) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &gmailfilter_terraforming.GmailfilterProvider{}, []string{p.Credentials, p.Email}
+}
+
+type Vault struct {
+	Address string
+	Token   string
+	// MaxDepth limits the folder depth listed for kv secrets, 0 is unlimited
+	MaxDepth           int
+	SecretsAsVariables bool
+	Namespace          string
+}
+
+func (p Vault) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &vault_terraforming.Provider{}, []string{p.Address, p.Token, strconv.Itoa(p.MaxDepth), strconv.FormatBool(p.SecretsAsVariables), p.Namespace}
+}
+
+type Okta struct {
+	OrgName string
+	Token   string
+	BaseURL string
+}
+
+func (p Okta) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &okta_terraforming.OktaProvider{}, []string{p.OrgName, p.Token, p.BaseURL}
+}
+
+type Auth0 struct {
+	Domain       string
+	ClientID     string
+	ClientSecret string
+}
+
+func (p Auth0) ProviderGenerator() (terraformutils.ProviderGenerator, []string) {
+	return &auth0_terraforming.Auth0Provider{}, []string{p.Domain, p.ClientID, p.ClientSecret}
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// Stream imports services one at a time: each service is listed, refreshed, connected and
+// written before the next one starts, so memory is bounded by the largest service.
+// Services are connected through an on-disk index of the values they link to, services
+// another service links to are imported first. Result.Resources and Result.Files stay empty,
+// generated files are only passed to options.Writer.
+func Stream(ctx context.Context, generator terraformutils.ProviderGenerator, args []string, options Options) (*Result, error) {
+	options = options.withDefaults()
+	if !strings.Contains(options.PathPattern, "{service}") {
+		return nil, errors.New("streaming requires a path pattern with {service}")
+	}
+	if options.Writer == nil {
+		return nil, errors.New("streaming requires a writer")
+	}
+	ctx, cancel := withTimeout(ctx, options.Timeout)
+	defer cancel()
+	result, providerWrapper, err := startImport(ctx, generator, args, options)
+	if err != nil {
+		return nil, err
+	}
+	defer providerWrapper.Kill()
+
+	parameters, err := environmentParameters(ctx, generator, options)
+	if err != nil {
+		return nil, err
+	}
+
+	indexDir, err := os.MkdirTemp("", "terraformer-index-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(indexDir)
+	index := terraformutils.NewConnectionIndex(indexDir)
+
+	connections := generator.GetResourceConnections()
+	dataSources := connectionDataSources(generator, options)
+	services := connectionOrder(Services(generator, options.Resources, options.Excludes), connections)
+	importedServices := map[string]bool{}
+	for _, service := range services {
+		importedServices[service] = true
+	}
+	for _, service := range services {
+		if err := refreshServices(ctx, generator, args, []string{service}, options, providerWrapper, result); err != nil {
+			return nil, err
+		}
+		if result.Errors[service] != nil {
+			delete(importedServices, service)
+			continue
+		}
+		resources := result.Resources[service]
+		// release the resources of the service once written
+		delete(result.Resources, service)
+		if options.Connect {
+			if err := index.Connect(service, resources, connections); err != nil {
+				return nil, err
+			}
+			if dataSources != nil {
+				terraformutils.ConnectDataSources(service, resources, unindexedConnections(service, connections, importedServices, index), dataSources)
+			}
+		}
+		if err := writeService(options.Writer, generator, service, options, resources, importedServices, parameters, result.ImpliedTypes); err != nil {
+			return nil, err
+		}
+		if options.Connect {
+			if err := index.Add(service, resources, connections); err != nil {
+				return nil, err
+			}
+		}
+		log.Printf("%s streamed %d resources of %s\n", generator.GetName(), len(resources), service)
+	}
+	return result, nil
+}
+
+// unindexedConnections are the connections of service to services that aren't imported, or
+// failed to, the other services are in the index or link to service once imported
+func unindexedConnections(service string, connections map[string]map[string][]string, importedServices map[string]bool, index *terraformutils.ConnectionIndex) map[string]map[string][]string {
+	unindexed := map[string][]string{}
+	for k, connectionPairs := range connections[service] {
+		if !importedServices[k] || index.Has(k) {
+			unindexed[k] = connectionPairs
+		}
+	}
+	return map[string]map[string][]string{service: unindexed}
+}
+
+// connectionOrder sorts services so the services a service links to come first,
+// services linking to each other keep their order
+func connectionOrder(services []string, connections map[string]map[string][]string) []string {
+	requested := map[string]bool{}
+	for _, service := range services {
+		requested[service] = true
+	}
+	ordered := make([]string, 0, len(services))
+	visited := map[string]bool{}
+	var visit func(service string)
+	visit = func(service string) {
+		if visited[service] {
+			return
+		}
+		visited[service] = true
+		dependencies := make([]string, 0, len(connections[service]))
+		for dependency := range connections[service] {
+			if requested[dependency] {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			visit(dependency)
+		}
+		ordered = append(ordered, service)
+	}
+	for _, service := range services {
+		visit(service)
+	}
+	return ordered
+}
@@ -0,0 +1,190 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// RecordEnv records cassettes against the real APIs when set to 1, credentials come from the
+// environment as they do for imports
+const RecordEnv = "TERRAFORMER_RECORD"
+
+// Cassette holds the HTTP interactions of a test in the order they were recorded
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and its response, request headers are never recorded so
+// cassettes don't leak credentials
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper replaying a cassette, or recording it when RecordEnv is set.
+// Requests are matched on method, URL and body, identical requests replay in recorded order.
+type Recorder struct {
+	path      string
+	recording bool
+	transport http.RoundTripper
+	mutex     sync.Mutex
+	cassette  Cassette
+	replayed  []bool
+}
+
+// NewRecorder loads the cassette at path, in record mode the cassette is written when t ends
+func NewRecorder(t testing.TB, path string) *Recorder {
+	t.Helper()
+	r := &Recorder{
+		path:      path,
+		recording: os.Getenv(RecordEnv) == "1",
+		transport: http.DefaultTransport,
+	}
+	if r.recording {
+		t.Cleanup(func() {
+			if err := r.save(); err != nil {
+				t.Errorf("saving cassette %s: %s", path, err)
+			}
+		})
+		return r
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette, record it with %s=1: %s", RecordEnv, err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		t.Fatalf("reading cassette %s: %s", path, err)
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r
+}
+
+// Client returns an HTTP client going through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := RecordedRequest{Method: req.Method, URL: req.URL.String(), Body: body}
+	if r.recording {
+		return r.record(req, recorded)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request != recorded {
+			continue
+		}
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette %s has no interaction left for %s %s, record it again with %s=1", r.path, req.Method, recorded.URL, RecordEnv)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: string(body)},
+	})
+	r.mutex.Unlock()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (r *Recorder) save() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+// readBody reads the request body and restores it for the real transport
+func readBody(req *http.Request) (string, error) {
+	if req.Body == nil {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
+
+// UseDefaultTransport routes http.DefaultTransport through transport until t ends, for SDKs
+// that don't take an HTTP client. Tests using it can't run in parallel.
+func UseDefaultTransport(t testing.TB, transport http.RoundTripper) {
+	t.Helper()
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	t.Cleanup(func() {
+		http.DefaultTransport = defaultTransport
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformertest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Link", `<next>; rel="next"`)
+		fmt.Fprintf(w, "page %d", calls)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		client := NewRecorder(t, path).Client()
+		for _, want := range []string{"page 1", "page 2"} {
+			if got := get(t, client, server.URL); got != want {
+				t.Errorf("recording got %q, want %q", got, want)
+			}
+		}
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("cassette records request headers:\n%s", data)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		recorder := NewRecorder(t, path)
+		client := recorder.Client()
+		for _, want := range []string{"page 1", "page 2"} {
+			if got := get(t, client, server.URL); got != want {
+				t.Errorf("replaying got %q, want %q", got, want)
+			}
+		}
+		if _, err := client.Get(server.URL); err == nil || !strings.Contains(err.Error(), "no interaction left") {
+			t.Errorf("expected an error once interactions are replayed, got %v", err)
+		}
+		for _, interaction := range recorder.cassette.Interactions {
+			if interaction.Response.Header.Get("Link") == "" {
+				t.Error("response headers aren't recorded")
+			}
+		}
+	})
+	if calls != 2 {
+		t.Errorf("server got %d calls, replay shouldn't reach it", calls)
+	}
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "token secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformertest
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest/fakeplugin"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const fakePluginPackage = "github.com/GoogleCloudPlatform/terraformer/terraformer/terraformertest/fakeplugin/terraform-provider-fake"
+
+// InstallFakePlugin builds terraform-provider-fake into a temporary TF_DATA_DIR, where imports
+// resolve the fake provider as registry.terraform.io/terraformer/fake 0.0.1. HOME and the CLI
+// config are moved too so installed providers don't interfere.
+func InstallFakePlugin(t testing.TB) {
+	t.Helper()
+	dataDir := t.TempDir()
+	pluginDir := filepath.Join(dataDir, "providers", "registry.terraform.io", "terraformer", "fake", "0.0.1", runtime.GOOS+"_"+runtime.GOARCH)
+	build := exec.Command("go", "build", "-o", filepath.Join(pluginDir, "terraform-provider-fake_v0.0.1"), fakePluginPackage)
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		t.Fatalf("can't build fake provider: %v", err)
+	}
+	t.Setenv("TF_DATA_DIR", dataDir)
+	t.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(dataDir, "terraformrc"))
+	t.Setenv("HOME", dataDir)
+}
+
+// FakeGenerator is the provider generator of the fake plugin, its only arg is the path of the
+// backend, see FakeArgs. Its services list the objects of the backend: networks lists fake_network
+// objects and instances lists fake_instance objects, which connect to networks by network_id.
+type FakeGenerator struct { //nolint
+	terraformutils.Provider
+	backendPath string
+	backend     fakeplugin.Backend
+}
+
+// FakeArgs writes backend to a temporary file read by both the generator and the plugin and
+// returns the args of FakeGenerator
+func FakeArgs(t testing.TB, backend fakeplugin.Backend) []string {
+	t.Helper()
+	data, err := json.Marshal(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "backend.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return []string{path}
+}
+
+func (p *FakeGenerator) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("fake: backend path is required")
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	p.backendPath = args[0]
+	return json.Unmarshal(data, &p.backend)
+}
+
+func (p *FakeGenerator) GetName() string {
+	return "fake"
+}
+
+func (p *FakeGenerator) GetSource() string {
+	return "terraformer/fake"
+}
+
+func (p *FakeGenerator) GetConfig() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{"backend": cty.StringVal(p.backendPath)})
+}
+
+func (p *FakeGenerator) GetProviderData(arg ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"provider": map[string]interface{}{
+			"fake": map[string]interface{}{
+				"backend": p.backendPath,
+			},
+		},
+	}
+}
+
+func (p *FakeGenerator) GetResourceConnections() map[string]map[string][]string {
+	return map[string]map[string][]string{
+		"instances": {"networks": []string{"network_id", "id"}},
+	}
+}
+
+func (p *FakeGenerator) GetSupportedService() map[string]terraformutils.ServiceGenerator {
+	return map[string]terraformutils.ServiceGenerator{
+		"networks":  &fakeService{resourceType: "fake_network"},
+		"instances": &fakeService{resourceType: "fake_instance"},
+	}
+}
+
+func (p *FakeGenerator) InitService(serviceName string, verbose bool) error {
+	service, isSupported := p.GetSupportedService()[serviceName]
+	if !isSupported {
+		return errors.New(p.GetName() + ": " + serviceName + " not supported service")
+	}
+	p.Service = service
+	p.Service.SetName(serviceName)
+	p.Service.SetVerbose(verbose)
+	p.Service.SetProviderName(p.GetName())
+	p.Service.SetArgs(map[string]interface{}{"backend": p.backend})
+	return nil
+}
+
+type fakeService struct {
+	terraformutils.Service
+	resourceType string
+}
+
+// InitResources lists the objects of the resource type by ID, named after their name attribute
+func (s *fakeService) InitResources() error {
+	objects := s.Args["backend"].(fakeplugin.Backend).Objects[s.resourceType]
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		name := id
+		_ = json.Unmarshal(objects[id]["name"], &name)
+		s.Resources = append(s.Resources, terraformutils.NewSimpleResource(id, name, s.resourceType, "fake", []string{}))
+	}
+	return nil
+}
@@ -0,0 +1,424 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakeplugin is a provider plugin speaking protocol 6 for end to end tests. It reads
+// remote objects from a Backend file and can be configured to be slow, throttled or failing.
+// terraform-provider-fake serves it, terraformertest.InstallFakePlugin builds and installs it.
+package fakeplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"github.com/zclconf/go-cty/cty/msgpack"
+	"google.golang.org/grpc"
+
+	proto "github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/tfplugin6"
+)
+
+// Backend is the remote side of the fake provider, it is passed to the plugin as a JSON file
+// named by the "backend" provider attribute
+type Backend struct {
+	// Objects holds JSON attributes of remote objects by resource type and ID, missing
+	// attributes are null
+	Objects map[string]map[string]map[string]json.RawMessage `json:"objects"`
+	// LatencyMs delays every read and import
+	LatencyMs int `json:"latency_ms,omitempty"`
+	// ThrottledReads is the number of reads of each resource failing with a throttling error
+	// before it can be read, the refresh retries them
+	ThrottledReads int `json:"throttled_reads,omitempty"`
+	// FailReads are IDs that can't be read, the refresh falls back to import
+	FailReads []string `json:"fail_reads,omitempty"`
+	// FailImports are IDs that can't be imported
+	FailImports []string `json:"fail_imports,omitempty"`
+	// Defaults are the JSON values planned for attributes left out of the configuration, by
+	// resource type and attribute
+	Defaults map[string]map[string]json.RawMessage `json:"defaults,omitempty"`
+}
+
+// ProviderSchema configures the plugin
+var ProviderSchema = &configschema.Block{
+	Attributes: map[string]*configschema.Attribute{
+		"backend": {Type: cty.String, Required: true},
+	},
+}
+
+// ResourceSchemas cover the shapes refresh and conversion handle: nested list and set blocks,
+// maps, sets, computed and sensitive attributes. fake_instance is at schema version 1.
+var ResourceSchemas = map[string]*configschema.Block{
+	"fake_network": {
+		Attributes: map[string]*configschema.Attribute{
+			"id":          {Type: cty.String, Optional: true, Computed: true},
+			"name":        {Type: cty.String, Required: true},
+			"cidr":        {Type: cty.String, Optional: true},
+			"description": {Type: cty.String, Optional: true},
+			"tags":        {Type: cty.Map(cty.String), Optional: true},
+			"urn":         {Type: cty.String, Computed: true},
+			"created_at":  {Type: cty.String, Computed: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"subnet": {
+				Nesting: configschema.NestingSet,
+				Block: configschema.Block{Attributes: map[string]*configschema.Attribute{
+					"name":    {Type: cty.String, Required: true},
+					"cidr":    {Type: cty.String, Required: true},
+					"gateway": {Type: cty.String, Computed: true},
+				}},
+			},
+			"route": {
+				Nesting: configschema.NestingList,
+				Block: configschema.Block{Attributes: map[string]*configschema.Attribute{
+					"destination": {Type: cty.String, Required: true},
+					"next_hop":    {Type: cty.String, Optional: true},
+				}},
+			},
+		},
+	},
+	"fake_instance": {
+		Attributes: map[string]*configschema.Attribute{
+			"id":             {Type: cty.String, Optional: true, Computed: true},
+			"name":           {Type: cty.String, Required: true},
+			"network_id":     {Type: cty.String, Required: true},
+			"size":           {Type: cty.String, Optional: true},
+			"zones":          {Type: cty.Set(cty.String), Optional: true},
+			"metadata":       {Type: cty.Map(cty.String), Optional: true},
+			"admin_password": {Type: cty.String, Optional: true, Sensitive: true},
+			"fingerprint":    {Type: cty.String, Computed: true},
+			"status":         {Type: cty.String, Computed: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"disk": {
+				Nesting:  configschema.NestingList,
+				MaxItems: 1,
+				Block: configschema.Block{Attributes: map[string]*configschema.Attribute{
+					"size_gb":     {Type: cty.Number, Optional: true},
+					"type":        {Type: cty.String, Optional: true},
+					"device_name": {Type: cty.String, Computed: true},
+				}},
+			},
+		},
+	},
+}
+
+var schemaVersions = map[string]int64{"fake_instance": 1}
+
+type server struct {
+	proto.UnimplementedProviderServer
+	mutex     sync.Mutex
+	backend   Backend
+	throttled map[string]int
+}
+
+func (s *server) GetProviderSchema(context.Context, *proto.GetProviderSchema_Request) (*proto.GetProviderSchema_Response, error) {
+	resp := &proto.GetProviderSchema_Response{
+		Provider:        &proto.Schema{Block: protoBlock(ProviderSchema)},
+		ResourceSchemas: map[string]*proto.Schema{},
+	}
+	for name, block := range ResourceSchemas {
+		resp.ResourceSchemas[name] = &proto.Schema{Version: schemaVersions[name], Block: protoBlock(block)}
+	}
+	return resp, nil
+}
+
+func (s *server) ValidateProviderConfig(_ context.Context, req *proto.ValidateProviderConfig_Request) (*proto.ValidateProviderConfig_Response, error) {
+	return &proto.ValidateProviderConfig_Response{}, nil
+}
+
+func (s *server) ConfigureProvider(_ context.Context, req *proto.ConfigureProvider_Request) (*proto.ConfigureProvider_Response, error) {
+	config, err := msgpack.Unmarshal(req.Config.Msgpack, ProviderSchema.ImpliedType())
+	if err != nil {
+		return nil, err
+	}
+	if config.GetAttr("backend").IsNull() {
+		return &proto.ConfigureProvider_Response{Diagnostics: diagnostics(errors.New("backend is required"))}, nil
+	}
+	data, err := os.ReadFile(config.GetAttr("backend").AsString())
+	if err != nil {
+		return &proto.ConfigureProvider_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := json.Unmarshal(data, &s.backend); err != nil {
+		return &proto.ConfigureProvider_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	s.throttled = map[string]int{}
+	return &proto.ConfigureProvider_Response{}, nil
+}
+
+// ReadResource returns the remote object over the prior state, a null state when the object
+// doesn't exist anymore
+func (s *server) ReadResource(_ context.Context, req *proto.ReadResource_Request) (*proto.ReadResource_Response, error) {
+	ty, err := impliedType(req.TypeName)
+	if err != nil {
+		return &proto.ReadResource_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	prior, err := msgpack.Unmarshal(req.CurrentState.Msgpack, ty)
+	if err != nil {
+		return nil, err
+	}
+	id := ""
+	if !prior.IsNull() && !prior.GetAttr("id").IsNull() {
+		id = prior.GetAttr("id").AsString()
+	}
+	s.wait()
+	if err := s.readError(req.TypeName, id); err != nil {
+		return &proto.ReadResource_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	state, exist, err := s.object(req.TypeName, id, prior)
+	if err != nil {
+		return &proto.ReadResource_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	if !exist {
+		state = cty.NullVal(ty)
+	}
+	newState, err := msgpack.Marshal(state, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ReadResource_Response{NewState: &proto.DynamicValue{Msgpack: newState}, Private: req.Private}, nil
+}
+
+func (s *server) ImportResourceState(_ context.Context, req *proto.ImportResourceState_Request) (*proto.ImportResourceState_Response, error) {
+	ty, err := impliedType(req.TypeName)
+	if err != nil {
+		return &proto.ImportResourceState_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	s.wait()
+	if contains(s.backend.FailImports, req.Id) {
+		return &proto.ImportResourceState_Response{Diagnostics: diagnostics(fmt.Errorf("importing %s failed", req.Id))}, nil
+	}
+	state, exist, err := s.object(req.TypeName, req.Id, cty.NullVal(ty))
+	if err != nil {
+		return &proto.ImportResourceState_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	if !exist {
+		return &proto.ImportResourceState_Response{Diagnostics: diagnostics(fmt.Errorf("cannot import non-existent remote object %s", req.Id))}, nil
+	}
+	mp, err := msgpack.Marshal(state, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ImportResourceState_Response{ImportedResources: []*proto.ImportResourceState_ImportedResource{
+		{TypeName: req.TypeName, State: &proto.DynamicValue{Msgpack: mp}},
+	}}, nil
+}
+
+// UpgradeResourceState decodes JSON and flatmap states, every recorded version shares the current schema
+func (s *server) UpgradeResourceState(_ context.Context, req *proto.UpgradeResourceState_Request) (*proto.UpgradeResourceState_Response, error) {
+	ty, err := impliedType(req.TypeName)
+	if err != nil {
+		return &proto.UpgradeResourceState_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	var state cty.Value
+	if req.RawState != nil && len(req.RawState.Json) > 0 {
+		state, err = ctyjson.Unmarshal(req.RawState.Json, ty)
+	} else if req.RawState != nil {
+		state, err = hcl2shim.HCL2ValueFromFlatmap(req.RawState.Flatmap, ty)
+	} else {
+		err = errors.New("empty raw state")
+	}
+	if err != nil {
+		return &proto.UpgradeResourceState_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	mp, err := msgpack.Marshal(state, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.UpgradeResourceState_Response{UpgradedState: &proto.DynamicValue{Msgpack: mp}}, nil
+}
+
+// PlanResourceChange plans the proposed state with the Defaults of the attributes the
+// configuration leaves null
+func (s *server) PlanResourceChange(_ context.Context, req *proto.PlanResourceChange_Request) (*proto.PlanResourceChange_Response, error) {
+	ty, err := impliedType(req.TypeName)
+	if err != nil {
+		return &proto.PlanResourceChange_Response{Diagnostics: diagnostics(err)}, nil
+	}
+	config, err := msgpack.Unmarshal(req.Config.Msgpack, ty)
+	if err != nil {
+		return nil, err
+	}
+	proposed, err := msgpack.Unmarshal(req.ProposedNewState.Msgpack, ty)
+	if err != nil {
+		return nil, err
+	}
+	planned := proposed.AsValueMap()
+	for name, data := range s.backend.Defaults[req.TypeName] {
+		if !ty.HasAttribute(name) || !config.GetAttr(name).IsNull() {
+			continue
+		}
+		value, err := ctyjson.Unmarshal(data, ty.AttributeType(name))
+		if err != nil {
+			return &proto.PlanResourceChange_Response{Diagnostics: diagnostics(err)}, nil
+		}
+		planned[name] = value
+	}
+	mp, err := msgpack.Marshal(cty.ObjectVal(planned), ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.PlanResourceChange_Response{PlannedState: &proto.DynamicValue{Msgpack: mp}}, nil
+}
+
+func (s *server) StopProvider(context.Context, *proto.StopProvider_Request) (*proto.StopProvider_Response, error) {
+	return &proto.StopProvider_Response{}, nil
+}
+
+func (s *server) wait() {
+	if s.backend.LatencyMs > 0 {
+		time.Sleep(time.Duration(s.backend.LatencyMs) * time.Millisecond)
+	}
+}
+
+// readError throttles the first reads of each resource and fails the reads of FailReads
+func (s *server) readError(typeName, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key := typeName + "." + id
+	if s.throttled[key] < s.backend.ThrottledReads {
+		s.throttled[key]++
+		return fmt.Errorf("throttling: rate exceeded reading %s", id)
+	}
+	if contains(s.backend.FailReads, id) {
+		return fmt.Errorf("reading %s failed", id)
+	}
+	return nil
+}
+
+// object overlays the remote object of id on prior, ok is false when it doesn't exist
+func (s *server) object(typeName, id string, prior cty.Value) (cty.Value, bool, error) {
+	ty := prior.Type()
+	object, exist := s.backend.Objects[typeName][id]
+	if !exist {
+		return cty.NilVal, false, nil
+	}
+	attributes := map[string]json.RawMessage{}
+	if !prior.IsNull() {
+		data, err := ctyjson.Marshal(prior, ty)
+		if err != nil {
+			return cty.NilVal, false, err
+		}
+		if err := json.Unmarshal(data, &attributes); err != nil {
+			return cty.NilVal, false, err
+		}
+	}
+	for name, value := range object {
+		attributes[name] = value
+	}
+	attributes["id"], _ = json.Marshal(id)
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return cty.NilVal, false, err
+	}
+	value, err := ctyjson.Unmarshal(data, ty)
+	if err != nil {
+		return cty.NilVal, false, fmt.Errorf("object %s of %s doesn't match the schema: %w", id, typeName, err)
+	}
+	return value, true, nil
+}
+
+func impliedType(typeName string) (cty.Type, error) {
+	block, exist := ResourceSchemas[typeName]
+	if !exist {
+		return cty.NilType, fmt.Errorf("unknown resource type %s", typeName)
+	}
+	return block.ImpliedType(), nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func diagnostics(err error) []*proto.Diagnostic {
+	return []*proto.Diagnostic{{Severity: proto.Diagnostic_ERROR, Summary: err.Error()}}
+}
+
+var protoNesting = map[configschema.NestingMode]proto.Schema_NestedBlock_NestingMode{
+	configschema.NestingSingle: proto.Schema_NestedBlock_SINGLE,
+	configschema.NestingGroup:  proto.Schema_NestedBlock_GROUP,
+	configschema.NestingList:   proto.Schema_NestedBlock_LIST,
+	configschema.NestingSet:    proto.Schema_NestedBlock_SET,
+	configschema.NestingMap:    proto.Schema_NestedBlock_MAP,
+}
+
+func protoBlock(block *configschema.Block) *proto.Schema_Block {
+	converted := &proto.Schema_Block{}
+	for name, attribute := range block.Attributes {
+		ty, err := ctyjson.MarshalType(attribute.Type)
+		if err != nil {
+			panic(err)
+		}
+		converted.Attributes = append(converted.Attributes, &proto.Schema_Attribute{
+			Name:      name,
+			Type:      ty,
+			Required:  attribute.Required,
+			Optional:  attribute.Optional,
+			Computed:  attribute.Computed,
+			Sensitive: attribute.Sensitive,
+		})
+	}
+	for name, nested := range block.BlockTypes {
+		converted.BlockTypes = append(converted.BlockTypes, &proto.Schema_NestedBlock{
+			TypeName: name,
+			Block:    protoBlock(&nested.Block),
+			Nesting:  protoNesting[nested.Nesting],
+			MinItems: int64(nested.MinItems),
+			MaxItems: int64(nested.MaxItems),
+		})
+	}
+	return converted
+}
+
+type providerPlugin struct {
+	plugin.Plugin
+}
+
+func (p *providerPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterProviderServer(s, &server{})
+	return nil
+}
+
+func (p *providerPlugin) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, errors.New("server only")
+}
+
+// Serve serves the plugin until terraformer kills it
+func Serve() {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: plugin.HandshakeConfig{
+			ProtocolVersion:  6,
+			MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+			MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+		},
+		VersionedPlugins: map[int]plugin.PluginSet{
+			6: {"provider": &providerPlugin{}},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package terraformertest runs provider generators without credentials: a Recorder replays
+// the API traffic of InitResources from a cassette, a FakeProvider replaces the provider
+// plugin during refresh and Golden compares the generated HCL with golden files.
+package terraformertest
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformer"
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils"
+)
+
+// UpdateEnv rewrites golden files from the generated ones when set to 1
+const UpdateEnv = "TERRAFORMER_UPDATE_GOLDEN"
+
+// goldenSkipped are generated files that depend on the installed provider or change on every run
+var goldenSkipped = map[string]bool{
+	"provider.tf":         true,
+	"provider.tf.json":    true,
+	".terraform.lock.hcl": true,
+	"terraform.tfstate":   true,
+}
+
+// Golden imports generator as the CLI would and compares the generated files with the files
+// under dir, paths are relative to the output directory, e.g. github/teams/github_team.tf.
+// Set options.Plugin to a FakeProvider or install a plugin such as the one of InstallFakePlugin,
+// the other options default as for the library. Provider files and states are skipped as they
+// depend on the environment.
+func Golden(t *testing.T, generator terraformutils.ProviderGenerator, args []string, options terraformer.Options, dir string) {
+	t.Helper()
+	if options.Plugin != nil {
+		// no plugin is resolved, keep provider lookups off the machine's plugin directories
+		t.Setenv("TF_DATA_DIR", t.TempDir())
+	}
+	if options.PathOutput == "" {
+		options.PathOutput = terraformer.DefaultPathOutput
+	}
+	if options.RetryCount == 0 {
+		options.RetryCount = 1
+	}
+	options.Writer = nil
+	result, err := terraformer.ImportGenerator(context.Background(), generator, args, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := map[string][]byte{}
+	for path, data := range result.Files {
+		if goldenSkipped[filepath.Base(path)] {
+			continue
+		}
+		relative, err := filepath.Rel(options.PathOutput, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated[filepath.ToSlash(relative)] = data
+	}
+
+	if os.Getenv(UpdateEnv) == "1" {
+		updateGolden(t, dir, generated)
+		return
+	}
+	golden := readGolden(t, dir)
+	for _, path := range sortedKeys(generated) {
+		want, exist := golden[path]
+		if !exist {
+			t.Errorf("%s is generated but isn't in %s, update golden files with %s=1", path, dir, UpdateEnv)
+			continue
+		}
+		if string(want) != string(generated[path]) {
+			t.Errorf("%s doesn't match its golden file, update golden files with %s=1\ngot:\n%s\nwant:\n%s", path, UpdateEnv, generated[path], want)
+		}
+	}
+	for _, path := range sortedKeys(golden) {
+		if _, exist := generated[path]; !exist {
+			t.Errorf("%s is in %s but isn't generated", path, dir)
+		}
+	}
+}
+
+func readGolden(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relative)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("reading golden files, write them with %s=1: %s", UpdateEnv, err)
+	}
+	return files
+}
+
+func updateGolden(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for path, data := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,329 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformertest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// FakeProvider is an in-process provider for the refresh step, set it as terraformer.Options.Plugin.
+// It serves a schema, usually loaded from `terraform providers schema -json`, and reads resources
+// from Objects, the remote objects as the real provider would read them.
+type FakeProvider struct {
+	Schema providers.GetSchemaResponse
+	// Objects holds JSON attributes of remote objects by resource type and ID, attributes missing
+	// from an object keep their prior value and resources without an object read as their prior state
+	Objects map[string]map[string]map[string]json.RawMessage
+}
+
+// NewFakeProvider loads the schema at schemaPath and, unless objectsPath is empty, the objects at objectsPath
+func NewFakeProvider(t testing.TB, schemaPath, objectsPath string) *FakeProvider {
+	t.Helper()
+	schema, err := LoadSchema(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &FakeProvider{Schema: schema, Objects: map[string]map[string]map[string]json.RawMessage{}}
+	if objectsPath == "" {
+		return p
+	}
+	data, err := os.ReadFile(objectsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &p.Objects); err != nil {
+		t.Fatalf("reading objects %s: %s", objectsPath, err)
+	}
+	return p
+}
+
+func (p *FakeProvider) GetSchema() providers.GetSchemaResponse {
+	return p.Schema
+}
+
+func (p *FakeProvider) PrepareProviderConfig(req providers.PrepareProviderConfigRequest) providers.PrepareProviderConfigResponse {
+	return providers.PrepareProviderConfigResponse{PreparedConfig: req.Config}
+}
+
+func (p *FakeProvider) ValidateResourceTypeConfig(providers.ValidateResourceTypeConfigRequest) providers.ValidateResourceTypeConfigResponse {
+	return providers.ValidateResourceTypeConfigResponse{}
+}
+
+func (p *FakeProvider) ValidateDataSourceConfig(providers.ValidateDataSourceConfigRequest) providers.ValidateDataSourceConfigResponse {
+	return providers.ValidateDataSourceConfigResponse{}
+}
+
+// UpgradeResourceState decodes the raw state with the current schema, the fake has a single schema version
+func (p *FakeProvider) UpgradeResourceState(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	var resp providers.UpgradeResourceStateResponse
+	ty, err := p.impliedType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	if len(req.RawStateJSON) > 0 {
+		resp.UpgradedState, err = ctyjson.Unmarshal(req.RawStateJSON, ty)
+	} else {
+		resp.UpgradedState, err = hcl2shim.HCL2ValueFromFlatmap(req.RawStateFlatmap, ty)
+	}
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+	}
+	return resp
+}
+
+func (p *FakeProvider) Configure(providers.ConfigureRequest) providers.ConfigureResponse {
+	return providers.ConfigureResponse{}
+}
+
+func (p *FakeProvider) Stop() error {
+	return nil
+}
+
+func (p *FakeProvider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	var resp providers.ReadResourceResponse
+	newState, err := p.read(req.TypeName, req.PriorState)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.NewState = newState
+	resp.Private = req.Private
+	return resp
+}
+
+func (p *FakeProvider) PlanResourceChange(providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	return providers.PlanResourceChangeResponse{Diagnostics: unsupported("PlanResourceChange")}
+}
+
+func (p *FakeProvider) ApplyResourceChange(providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	return providers.ApplyResourceChangeResponse{Diagnostics: unsupported("ApplyResourceChange")}
+}
+
+// ImportResourceState imports the object of the ID, or a state holding only the ID
+func (p *FakeProvider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	var resp providers.ImportResourceStateResponse
+	ty, err := p.impliedType(req.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	prior, err := ctyjson.Unmarshal([]byte(`{"id":`+jsonString(req.ID)+`}`), ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	state, err := p.read(req.TypeName, prior)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.ImportedResources = []providers.ImportedResource{{TypeName: req.TypeName, State: state}}
+	return resp
+}
+
+func (p *FakeProvider) ReadDataSource(providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	return providers.ReadDataSourceResponse{Diagnostics: unsupported("ReadDataSource")}
+}
+
+func (p *FakeProvider) Close() error {
+	return nil
+}
+
+// read overlays the object recorded for the ID of prior on prior
+func (p *FakeProvider) read(typeName string, prior cty.Value) (cty.Value, error) {
+	ty, err := p.impliedType(typeName)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	if prior.IsNull() || !prior.Type().HasAttribute("id") || prior.GetAttr("id").IsNull() {
+		return prior, nil
+	}
+	object, exist := p.Objects[typeName][prior.GetAttr("id").AsString()]
+	if !exist {
+		return prior, nil
+	}
+	data, err := ctyjson.Marshal(prior, ty)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	attributes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return cty.NilVal, err
+	}
+	for name, value := range object {
+		attributes[name] = value
+	}
+	data, err = json.Marshal(attributes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	value, err := ctyjson.Unmarshal(data, ty)
+	if err != nil {
+		return cty.NilVal, fmt.Errorf("object %s of %s doesn't match the schema: %w", prior.GetAttr("id").AsString(), typeName, err)
+	}
+	return value, nil
+}
+
+func (p *FakeProvider) impliedType(typeName string) (cty.Type, error) {
+	schema, exist := p.Schema.ResourceTypes[typeName]
+	if !exist || schema.Block == nil {
+		return cty.NilType, fmt.Errorf("resource type %s isn't in the fake provider schema", typeName)
+	}
+	return schema.Block.ImpliedType(), nil
+}
+
+func unsupported(method string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	return diags.Append(errors.New(method + " isn't supported by the fake provider"))
+}
+
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// providerSchemasJSON and the types below follow the output of `terraform providers schema -json`
+type providerSchemasJSON struct {
+	ProviderSchemas map[string]*providerSchemaJSON `json:"provider_schemas"`
+}
+
+type providerSchemaJSON struct {
+	Provider        *schemaJSON            `json:"provider"`
+	ResourceSchemas map[string]*schemaJSON `json:"resource_schemas"`
+}
+
+type schemaJSON struct {
+	Version int64      `json:"version"`
+	Block   *blockJSON `json:"block"`
+}
+
+type blockJSON struct {
+	Attributes map[string]*attributeJSON `json:"attributes"`
+	BlockTypes map[string]*blockTypeJSON `json:"block_types"`
+}
+
+type attributeJSON struct {
+	Type      json.RawMessage `json:"type"`
+	Optional  bool            `json:"optional"`
+	Required  bool            `json:"required"`
+	Computed  bool            `json:"computed"`
+	Sensitive bool            `json:"sensitive"`
+}
+
+type blockTypeJSON struct {
+	NestingMode string     `json:"nesting_mode"`
+	Block       *blockJSON `json:"block"`
+	MinItems    int        `json:"min_items"`
+	MaxItems    int        `json:"max_items"`
+}
+
+var nestingModes = map[string]configschema.NestingMode{
+	"single": configschema.NestingSingle,
+	"group":  configschema.NestingGroup,
+	"list":   configschema.NestingList,
+	"set":    configschema.NestingSet,
+	"map":    configschema.NestingMap,
+}
+
+// LoadSchema reads the output of `terraform providers schema -json` for a single provider,
+// trimming it to the resource types a test uses keeps fixtures small
+func LoadSchema(path string) (providers.GetSchemaResponse, error) {
+	var resp providers.GetSchemaResponse
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return resp, err
+	}
+	var schemas providerSchemasJSON
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return resp, fmt.Errorf("reading schema %s: %w", path, err)
+	}
+	if len(schemas.ProviderSchemas) != 1 {
+		return resp, fmt.Errorf("schema %s holds %d providers, expected 1", path, len(schemas.ProviderSchemas))
+	}
+	for _, schema := range schemas.ProviderSchemas {
+		if schema.Provider != nil {
+			if resp.Provider, err = convertSchema(schema.Provider); err != nil {
+				return resp, err
+			}
+		} else {
+			resp.Provider = providers.Schema{Block: &configschema.Block{}}
+		}
+		resp.ResourceTypes = map[string]providers.Schema{}
+		for name, resourceSchema := range schema.ResourceSchemas {
+			if resp.ResourceTypes[name], err = convertSchema(resourceSchema); err != nil {
+				return resp, fmt.Errorf("reading schema of %s: %w", name, err)
+			}
+		}
+	}
+	resp.DataSources = map[string]providers.Schema{}
+	return resp, nil
+}
+
+func convertSchema(schema *schemaJSON) (providers.Schema, error) {
+	block, err := convertBlock(schema.Block)
+	return providers.Schema{Version: schema.Version, Block: block}, err
+}
+
+func convertBlock(block *blockJSON) (*configschema.Block, error) {
+	converted := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{},
+		BlockTypes: map[string]*configschema.NestedBlock{},
+	}
+	if block == nil {
+		return converted, nil
+	}
+	for name, attribute := range block.Attributes {
+		ty, err := ctyjson.UnmarshalType(attribute.Type)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		converted.Attributes[name] = &configschema.Attribute{
+			Type:      ty,
+			Optional:  attribute.Optional,
+			Required:  attribute.Required,
+			Computed:  attribute.Computed,
+			Sensitive: attribute.Sensitive,
+		}
+	}
+	for name, blockType := range block.BlockTypes {
+		nesting, exist := nestingModes[blockType.NestingMode]
+		if !exist {
+			return nil, fmt.Errorf("block %s has unknown nesting mode %q", name, blockType.NestingMode)
+		}
+		nested, err := convertBlock(blockType.Block)
+		if err != nil {
+			return nil, err
+		}
+		converted.BlockTypes[name] = &configschema.NestedBlock{
+			Block:    *nested,
+			Nesting:  nesting,
+			MinItems: blockType.MinItems,
+			MaxItems: blockType.MaxItems,
+		}
+	}
+	return converted, nil
+}
@@ -0,0 +1,21 @@
+resource "fake_instance" "tfer--web" {
+  disk {
+    size_gb = "20"
+    type    = "ssd"
+  }
+
+  metadata = {
+    role = "web"
+  }
+
+  name       = "web"
+  network_id = "${data.terraform_remote_state.networks.outputs.fake_network_tfer--main_id}"
+  size       = "small"
+  zones      = ["z1", "z2"]
+}
+
+resource "fake_instance" "tfer--worker" {
+  name       = "worker"
+  network_id = "${data.terraform_remote_state.networks.outputs.fake_network_tfer--edge_id}"
+  size       = "large"
+}
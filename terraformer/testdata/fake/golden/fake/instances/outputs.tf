@@ -0,0 +1,7 @@
+output "fake_instance_tfer--web_id" {
+  value = "${fake_instance.tfer--web.id}"
+}
+
+output "fake_instance_tfer--worker_id" {
+  value = "${fake_instance.tfer--worker.id}"
+}
@@ -0,0 +1,7 @@
+data "terraform_remote_state" "networks" {
+  backend = "local"
+
+  config = {
+    path = "../../../generated/fake/networks/terraform.tfstate"
+  }
+}
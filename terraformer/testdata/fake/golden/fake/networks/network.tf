@@ -0,0 +1,33 @@
+resource "fake_network" "tfer--edge" {
+  cidr = "10.1.0.0/16"
+  name = "edge"
+}
+
+resource "fake_network" "tfer--main" {
+  cidr = "10.0.0.0/16"
+  name = "main"
+
+  route {
+    destination = "0.0.0.0/0"
+    next_hop    = "igw"
+  }
+
+  route {
+    destination = "10.1.0.0/16"
+  }
+
+  subnet {
+    cidr = "10.0.1.0/24"
+    name = "a"
+  }
+
+  subnet {
+    cidr = "10.0.2.0/24"
+    name = "b"
+  }
+
+  tags = {
+    env  = "prod"
+    team = "platform"
+  }
+}
@@ -0,0 +1,7 @@
+output "fake_network_tfer--edge_id" {
+  value = "${fake_network.tfer--edge.id}"
+}
+
+output "fake_network_tfer--main_id" {
+  value = "${fake_network.tfer--main.id}"
+}
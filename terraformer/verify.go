@@ -0,0 +1,287 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+)
+
+const (
+	// DefaultVerifyBinary is the terraform executable used by Verify, OpenTofu's tofu works too
+	DefaultVerifyBinary = "terraform"
+
+	DiffReplace = "would-replace"
+	DiffUpdate  = "would-update"
+	DiffDestroy = "would-destroy"
+	DiffCreate  = "would-create"
+)
+
+// VerifyOptions configures Verify
+type VerifyOptions struct {
+	// Binary is the terraform or tofu executable, DefaultVerifyBinary by default
+	Binary string
+}
+
+// Verification is the outcome of Verify, a zero-diff import has no diffs and no errors
+type Verification struct {
+	Directories []DirectoryVerification `json:"directories"`
+}
+
+// DirectoryVerification holds the plan of a generated directory
+type DirectoryVerification struct {
+	Path  string         `json:"path"`
+	Diffs []ResourceDiff `json:"diffs"`
+	// Error is set when the directory can't be planned
+	Error string `json:"error,omitempty"`
+}
+
+// ResourceDiff is a resource terraform would change, Attributes are the top level attributes
+// that differ between configuration and state
+type ResourceDiff struct {
+	Address    string   `json:"address"`
+	Kind       string   `json:"kind"`
+	Actions    []string `json:"actions"`
+	Attributes []string `json:"attributes,omitempty"`
+}
+
+// Diffs counts the diffs of every directory
+func (v *Verification) Diffs() int {
+	count := 0
+	for _, directory := range v.Directories {
+		count += len(directory.Diffs)
+	}
+	return count
+}
+
+// Err reports directories that have diffs or can't be planned
+func (v *Verification) Err() error {
+	failed := []string{}
+	for _, directory := range v.Directories {
+		if directory.Error != "" || len(directory.Diffs) > 0 {
+			failed = append(failed, directory.Path)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("verification found %d diffs, failed directories: %s", v.Diffs(), strings.Join(failed, ", "))
+}
+
+// StateDirs returns the directories of generated files holding a local state, in order
+func StateDirs(paths []string) []string {
+	dirs := []string{}
+	for _, path := range paths {
+		if filepath.Base(path) == "terraform.tfstate" {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Verify runs terraform init and plan in dirs, generated directories holding a local state,
+// and reports the resources whose configuration doesn't match their state. Terraform installs
+// the provider binary used for import and keeps its data out of dirs. A directory that can't be
+// planned is reported and the others are verified anyway.
+func Verify(ctx context.Context, providerName string, dirs []string, options VerifyOptions) (*Verification, error) {
+	if options.Binary == "" {
+		options.Binary = DefaultVerifyBinary
+	}
+	workDir, err := os.MkdirTemp("", "terraformer-verify-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+	pluginDir := filepath.Join(workDir, "plugins")
+	if err := providerwrapper.LinkProviderMirror(providerName, pluginDir); err != nil {
+		return nil, err
+	}
+
+	verification := &Verification{Directories: []DirectoryVerification{}}
+	for i, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		log.Println(providerName + " verifying " + dir)
+		directory := DirectoryVerification{Path: dir, Diffs: []ResourceDiff{}}
+		plan, err := planDirectory(ctx, options.Binary, dir, pluginDir, filepath.Join(workDir, fmt.Sprint(i)))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			directory.Error = err.Error()
+			log.Printf("ERROR: can't verify %s: %s\n", dir, err)
+		} else {
+			directory.Diffs = resourceDiffs(plan)
+			for _, diff := range directory.Diffs {
+				log.Printf("WARN: %s %s %s %s\n", dir, diff.Address, diff.Kind, strings.Join(diff.Attributes, ","))
+			}
+		}
+		verification.Directories = append(verification.Directories, directory)
+	}
+	return verification, nil
+}
+
+// planJSON and the types below follow the output of `terraform show -json` for a plan file
+type planJSON struct {
+	ResourceChanges []resourceChangeJSON `json:"resource_changes"`
+}
+
+type resourceChangeJSON struct {
+	Address string     `json:"address"`
+	Mode    string     `json:"mode"`
+	Change  changeJSON `json:"change"`
+}
+
+type changeJSON struct {
+	Actions      []string               `json:"actions"`
+	Before       map[string]interface{} `json:"before"`
+	After        map[string]interface{} `json:"after"`
+	AfterUnknown map[string]interface{} `json:"after_unknown"`
+}
+
+// planDirectory plans dir with its own data directory under workDir and returns the JSON plan
+func planDirectory(ctx context.Context, binary, dir, pluginDir, workDir string) (*planJSON, error) {
+	dataDir := filepath.Join(workDir, "data")
+	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	planFile := filepath.Join(workDir, "plan.out")
+	if _, err := runTerraform(ctx, binary, dir, dataDir, "init", "-input=false", "-no-color", "-plugin-dir="+pluginDir); err != nil {
+		return nil, err
+	}
+	if _, err := runTerraform(ctx, binary, dir, dataDir, "plan", "-input=false", "-no-color", "-lock=false", "-out="+planFile); err != nil {
+		return nil, err
+	}
+	output, err := runTerraform(ctx, binary, dir, dataDir, "show", "-json", "-no-color", planFile)
+	if err != nil {
+		return nil, err
+	}
+	plan := &planJSON{}
+	if err := json.Unmarshal(output, plan); err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	return plan, nil
+}
+
+func runTerraform(ctx context.Context, binary, dir, dataDir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "TF_DATA_DIR="+dataDir, "TF_IN_AUTOMATION=1", "TF_INPUT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s: %w\n%s", binary, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// resourceDiffs classifies the managed resource changes of plan, no-ops are dropped
+func resourceDiffs(plan *planJSON) []ResourceDiff {
+	diffs := []ResourceDiff{}
+	for _, change := range plan.ResourceChanges {
+		if change.Mode == "data" {
+			continue
+		}
+		kind := diffKind(change.Change.Actions)
+		if kind == "" {
+			continue
+		}
+		diff := ResourceDiff{Address: change.Address, Kind: kind, Actions: change.Change.Actions}
+		if kind == DiffUpdate || kind == DiffReplace {
+			diff.Attributes = changedAttributes(change.Change)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func diffKind(actions []string) string {
+	has := map[string]bool{}
+	for _, action := range actions {
+		has[action] = true
+	}
+	switch {
+	case has["delete"] && has["create"]:
+		return DiffReplace
+	case has["update"]:
+		return DiffUpdate
+	case has["delete"]:
+		return DiffDestroy
+	case has["create"]:
+		return DiffCreate
+	}
+	return ""
+}
+
+func changedAttributes(change changeJSON) []string {
+	attributes := []string{}
+	for name := range mergedKeys(change.Before, change.After, change.AfterUnknown) {
+		if hasUnknown(change.AfterUnknown[name]) {
+			attributes = append(attributes, name)
+			continue
+		}
+		if !reflect.DeepEqual(change.Before[name], change.After[name]) {
+			attributes = append(attributes, name)
+		}
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// hasUnknown reports whether an after_unknown value marks anything unknown, nested values
+// mirror the attribute structure
+func hasUnknown(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case []interface{}:
+		for _, element := range v {
+			if hasUnknown(element) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, element := range v {
+			if hasUnknown(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mergedKeys(maps ...map[string]interface{}) map[string]bool {
+	keys := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,141 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ConnectionIndex keeps on disk the values other services link to, so services can be
+// connected one at a time instead of keeping every resource in memory like ConnectServices
+type ConnectionIndex struct {
+	dir      string
+	services map[string]bool
+}
+
+// connectionIndexEntry is a value of a resource attribute and the output exposing it
+type connectionIndexEntry struct {
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+	Output    string `json:"output"`
+}
+
+func NewConnectionIndex(dir string) *ConnectionIndex {
+	return &ConnectionIndex{dir: dir, services: map[string]bool{}}
+}
+
+// Has reports whether service was added to the index
+func (i *ConnectionIndex) Has(service string) bool {
+	return i.services[service]
+}
+
+// Add records the attributes of resources that services connected to service link to
+func (i *ConnectionIndex) Add(service string, resources []Resource, resourceConnections map[string]map[string][]string) error {
+	attributes := map[string]bool{}
+	for _, connection := range resourceConnections {
+		connectionPairs := connection[service]
+		if len(connectionPairs)%2 == 1 {
+			continue
+		}
+		for j := 0; j < len(connectionPairs)/2; j++ {
+			attributes[connectionPairs[j*2+1]] = true
+		}
+	}
+
+	file, err := os.Create(i.path(service))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	for _, resource := range resources {
+		for attribute := range attributes {
+			key := attribute
+			if attribute == "self_link" || attribute == "id" {
+				key = resource.GetIDKey()
+			}
+			values := WalkAndGet(key, resource.InstanceState.Attributes)
+			if len(values) != 1 {
+				continue
+			}
+			value, ok := values[0].(string)
+			if !ok {
+				continue
+			}
+			err := encoder.Encode(connectionIndexEntry{
+				Attribute: attribute,
+				Value:     value,
+				Output:    resource.InstanceInfo.Type + "_" + resource.ResourceName + "_" + key,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	i.services[service] = true
+	return file.Close()
+}
+
+// Connect replaces values of resources with links to the outputs of services already in the index
+func (i *ConnectionIndex) Connect(service string, resources []Resource, resourceConnections map[string]map[string][]string) error {
+	for k, connectionPairs := range resourceConnections[service] {
+		if len(connectionPairs)%2 == 1 || !i.Has(k) {
+			continue
+		}
+		entries, err := i.entries(k)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < len(connectionPairs)/2; j++ {
+			for _, entry := range entries {
+				if entry.Attribute != connectionPairs[j*2+1] {
+					continue
+				}
+				linkValue := "${data.terraform_remote_state." + k + ".outputs." + entry.Output + "}"
+				for r := range resources {
+					WalkAndOverride(connectionPairs[j*2], entry.Value, linkValue, resources[r].Item)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (i *ConnectionIndex) entries(service string) ([]connectionIndexEntry, error) {
+	file, err := os.Open(i.path(service))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []connectionIndexEntry
+	decoder := json.NewDecoder(file)
+	for {
+		var entry connectionIndexEntry
+		if err := decoder.Decode(&entry); errors.Is(err, io.EOF) {
+			return entries, nil
+		} else if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func (i *ConnectionIndex) path(service string) string {
+	return filepath.Join(i.dir, service+".json")
+}
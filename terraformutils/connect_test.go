@@ -161,3 +161,35 @@ type MockedFlatmapParser struct {
 func (p *MockedFlatmapParser) Parse(ty cty.Type) (map[string]interface{}, error) {
 	return p.attributesParsed, nil
 }
+
+func TestConnectionIndex(t *testing.T) {
+	resourceConnections := map[string]map[string][]string{
+		"type1": {
+			"type2": {
+				"type2_ref1", "id",
+				"type2_ref2", "id",
+			},
+		},
+	}
+	index := NewConnectionIndex(t.TempDir())
+	if err := index.Add("type2", []Resource{prepareNoAttrs("ID2", "type2")}, resourceConnections); err != nil {
+		t.Fatal(err)
+	}
+	resources := []Resource{prepare("ID1", "type1", map[string]string{
+		"type2_ref1": "ID2",
+		"type2_ref2": "ID3",
+	}, map[string]interface{}{
+		"type2_ref1": "ID2",
+		"type2_ref2": "ID3",
+	})}
+	if err := index.Connect("type1", resources, resourceConnections); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(resources[0].Item, map[string]interface{}{
+		"type2_ref1": "${data.terraform_remote_state.type2.outputs.type2_tfer--name-type2_id}",
+		"type2_ref2": "ID3",
+	}) {
+		t.Errorf("failed to connect %v", resources[0].Item)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import "strings"
+
+// DataSource looks up the resources of a connected service by the attribute others link to
+type DataSource struct {
+	// Type is the data source type, e.g. aws_subnet
+	Type string
+	// Argument of the data source set to the value linked to, e.g. id
+	Argument string
+	// Attribute is the attribute of the resources linked to, and of the data source replacing
+	// their values, e.g. id
+	Attribute string
+}
+
+// ProviderWithDataSources is a provider able to look up the targets of GetResourceConnections
+// that aren't imported, data sources are keyed by connected service
+type ProviderWithDataSources interface {
+	GetDataSources() map[string]DataSource
+}
+
+// ConnectDataSources replaces the values connections of service still hold as literals, the
+// targets ConnectServices didn't find, with links to data sources looking them up. The data
+// sources are added to the DataSources of resources.
+func ConnectDataSources(service string, resources []Resource, resourceConnections map[string]map[string][]string, dataSources map[string]DataSource) {
+	for k, connectionPairs := range resourceConnections[service] {
+		dataSource, exist := dataSources[k]
+		if !exist || len(connectionPairs)%2 == 1 {
+			continue
+		}
+		for j := 0; j < len(connectionPairs)/2; j++ {
+			if connectionPairs[j*2+1] != dataSource.Attribute {
+				continue
+			}
+			for r := range resources {
+				connectDataSource(&resources[r], connectionPairs[j*2], dataSource)
+			}
+		}
+	}
+}
+
+func connectDataSource(resource *Resource, path string, dataSource DataSource) {
+	for _, value := range WalkAndGet(path, resource.Item) {
+		literal, ok := value.(string)
+		if !ok || literal == "" || strings.HasPrefix(literal, "${") {
+			continue
+		}
+		name := TfSanitize(literal)
+		if resource.DataSources == nil {
+			resource.DataSources = map[string]map[string]interface{}{}
+		}
+		if resource.DataSources[dataSource.Type] == nil {
+			resource.DataSources[dataSource.Type] = map[string]interface{}{}
+		}
+		resource.DataSources[dataSource.Type][name] = map[string]interface{}{
+			dataSource.Argument: literal,
+		}
+		linkValue := "${data." + dataSource.Type + "." + name + "." + dataSource.Attribute + "}"
+		WalkAndOverride(path, literal, linkValue, resource.Item)
+	}
+}
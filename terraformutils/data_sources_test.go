@@ -0,0 +1,83 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConnectDataSources(t *testing.T) {
+	connections := map[string]map[string][]string{
+		"ec2_instance": {
+			"sg":     []string{"vpc_security_group_ids", "id"},
+			"subnet": []string{"subnet_id", "id"},
+			"ebs":    []string{"ebs_block_device", "id"},
+		},
+		"subnet": {"vpc": []string{"vpc_id", "id"}},
+	}
+	dataSources := map[string]DataSource{
+		"sg":     {Type: "aws_security_group", Argument: "id", Attribute: "id"},
+		"subnet": {Type: "aws_subnet", Argument: "id", Attribute: "id"},
+		"vpc":    {Type: "aws_vpc", Argument: "id", Attribute: "id"},
+	}
+	instance := NewSimpleResource("i-1", "web", "aws_instance", "aws", nil)
+	instance.Item = map[string]interface{}{
+		"subnet_id": "subnet-1",
+		"vpc_security_group_ids": []interface{}{
+			"${data.terraform_remote_state.sg.outputs.aws_security_group_tfer--web_id}",
+			"sg-2",
+		},
+	}
+	subnet := NewSimpleResource("subnet-1", "subnet-1", "aws_subnet", "aws", nil)
+	subnet.InstanceState.Attributes["id"] = "subnet-1"
+	subnet.Item = map[string]interface{}{"vpc_id": "vpc-1"}
+	resources := map[string][]Resource{
+		"ec2_instance": {instance},
+		"subnet":       {subnet},
+	}
+	resources = ConnectServices(resources, true, connections)
+	for service := range resources {
+		ConnectDataSources(service, resources[service], connections, dataSources)
+	}
+
+	instance = resources["ec2_instance"][0]
+	expectedItem := map[string]interface{}{
+		"subnet_id": "${data.terraform_remote_state.subnet.outputs.aws_subnet_tfer--subnet-1_id}",
+		"vpc_security_group_ids": []interface{}{
+			"${data.terraform_remote_state.sg.outputs.aws_security_group_tfer--web_id}",
+			"${data.aws_security_group.tfer--sg-2.id}",
+		},
+	}
+	if !reflect.DeepEqual(instance.Item, expectedItem) {
+		t.Errorf("unexpected item %v, expected %v", instance.Item, expectedItem)
+	}
+	expectedDataSources := map[string]map[string]interface{}{
+		"aws_security_group": {"tfer--sg-2": map[string]interface{}{"id": "sg-2"}},
+	}
+	if !reflect.DeepEqual(instance.DataSources, expectedDataSources) {
+		t.Errorf("unexpected data sources %v, expected %v", instance.DataSources, expectedDataSources)
+	}
+	subnet = resources["subnet"][0]
+	if subnet.Item["vpc_id"] != "${data.aws_vpc.tfer--vpc-1.id}" || subnet.DataSources["aws_vpc"]["tfer--vpc-1"] == nil {
+		t.Errorf("unexpected subnet %v with data sources %v", subnet.Item, subnet.DataSources)
+	}
+
+	// connecting again links nothing new and keeps the data sources
+	ConnectDataSources("ec2_instance", resources["ec2_instance"], connections, dataSources)
+	if !reflect.DeepEqual(resources["ec2_instance"][0].DataSources, expectedDataSources) {
+		t.Errorf("connecting again changed data sources to %v", resources["ec2_instance"][0].DataSources)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTags describes the tags a provider applies to every resource it manages
+type DefaultTags struct {
+	// Attribute is the resource attribute holding tags, e.g. "tags"
+	Attribute string
+	// Dropped are attributes left out of resources once tags are lifted, e.g. "tags_all"
+	Dropped []string
+	// ProviderPath is the path of the tags map in the provider block, e.g. default_tags.tags
+	ProviderPath []string
+}
+
+// ProviderWithDefaultTags is a provider whose configuration can hold the tags common to resources
+type ProviderWithDefaultTags interface {
+	GetDefaultTags() DefaultTags
+}
+
+// LiftDefaultTags finds the tags common to every taggable resource, resources whose state has the
+// tags attribute, and returns the resources without them. Resources are copied, the tags of a
+// result can be lifted again for another output. Nothing is lifted from fewer than two resources.
+func LiftDefaultTags(resources []Resource, defaultTags DefaultTags) ([]Resource, map[string]interface{}) {
+	var common map[string]interface{}
+	taggable := 0
+	for _, resource := range resources {
+		if !hasAttribute(resource, defaultTags.Attribute) {
+			continue
+		}
+		taggable++
+		tags, _ := resource.Item[defaultTags.Attribute].(map[string]interface{})
+		if common == nil {
+			common = map[string]interface{}{}
+			for key, value := range tags {
+				common[key] = value
+			}
+			continue
+		}
+		for key, value := range common {
+			if tag, exist := tags[key]; !exist || fmt.Sprint(tag) != fmt.Sprint(value) {
+				delete(common, key)
+			}
+		}
+	}
+	if taggable < 2 {
+		common = nil
+	}
+
+	lifted := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		item := make(map[string]interface{}, len(resource.Item))
+		for key, value := range resource.Item {
+			item[key] = value
+		}
+		for _, dropped := range defaultTags.Dropped {
+			delete(item, dropped)
+		}
+		if tags, ok := item[defaultTags.Attribute].(map[string]interface{}); ok && len(common) > 0 {
+			remaining := map[string]interface{}{}
+			for key, value := range tags {
+				if _, exist := common[key]; !exist {
+					remaining[key] = value
+				}
+			}
+			if len(remaining) == 0 {
+				delete(item, defaultTags.Attribute)
+			} else {
+				item[defaultTags.Attribute] = remaining
+			}
+		}
+		resource.Item = item
+		lifted = append(lifted, resource)
+	}
+	return lifted, common
+}
+
+// AddDefaultTags sets tags at the ProviderPath of the providerName block of providerData and
+// returns the map paths to print as maps rather than blocks, see Print
+func AddDefaultTags(providerData map[string]interface{}, providerName string, defaultTags DefaultTags, tags map[string]interface{}) map[string]struct{} {
+	if len(tags) == 0 {
+		return map[string]struct{}{}
+	}
+	providers, _ := providerData["provider"].(map[string]interface{})
+	if providers == nil {
+		providers = map[string]interface{}{}
+		providerData["provider"] = providers
+	}
+	providers[providerName] = setPath(providers[providerName], defaultTags.ProviderPath, tags)
+	return map[string]struct{}{strings.Join(defaultTags.ProviderPath, "."): {}}
+}
+
+// hasAttribute reports whether the state or the item of resource has the attribute
+func hasAttribute(resource Resource, attribute string) bool {
+	if _, exist := resource.Item[attribute]; exist {
+		return true
+	}
+	if resource.InstanceState == nil {
+		return false
+	}
+	_, exist := resource.InstanceState.Attributes[attribute+".%"]
+	return exist
+}
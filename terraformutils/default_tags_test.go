@@ -0,0 +1,76 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLiftDefaultTags(t *testing.T) {
+	defaultTags := DefaultTags{Attribute: "tags", Dropped: []string{"tags_all"}, ProviderPath: []string{"default_tags", "tags"}}
+	tagged := func(name string, tags map[string]interface{}) Resource {
+		resource := NewSimpleResource(name, name, "aws_vpc", "aws", nil)
+		resource.InstanceState.Attributes["tags.%"] = "0"
+		resource.Item = map[string]interface{}{"cidr_block": "10.0.0.0/16", "tags_all": tags}
+		if tags != nil {
+			resource.Item["tags"] = tags
+		}
+		return resource
+	}
+	untagged := NewSimpleResource("r-1", "route", "aws_route", "aws", nil)
+	untagged.Item = map[string]interface{}{"destination_cidr_block": "0.0.0.0/0"}
+	resources := []Resource{
+		tagged("a", map[string]interface{}{"env": "prod", "team": "net", "Name": "a"}),
+		tagged("b", map[string]interface{}{"env": "prod", "team": "net", "Name": "b"}),
+		untagged,
+	}
+
+	lifted, tags := LiftDefaultTags(resources, defaultTags)
+	if expected := map[string]interface{}{"env": "prod", "team": "net"}; !reflect.DeepEqual(tags, expected) {
+		t.Errorf("lifted %v, expected %v", tags, expected)
+	}
+	expectedItems := []map[string]interface{}{
+		{"cidr_block": "10.0.0.0/16", "tags": map[string]interface{}{"Name": "a"}},
+		{"cidr_block": "10.0.0.0/16", "tags": map[string]interface{}{"Name": "b"}},
+		{"destination_cidr_block": "0.0.0.0/0"},
+	}
+	for i, resource := range lifted {
+		if !reflect.DeepEqual(resource.Item, expectedItems[i]) {
+			t.Errorf("unexpected item %v, expected %v", resource.Item, expectedItems[i])
+		}
+	}
+	if _, exist := resources[0].Item["tags_all"]; !exist {
+		t.Error("lifting tags changed the resources")
+	}
+
+	providerData := map[string]interface{}{"provider": map[string]interface{}{"aws": map[string]interface{}{"region": "eu-west-1"}}}
+	maps := AddDefaultTags(providerData, "aws", defaultTags, tags)
+	data, err := Print(providerData, maps, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"default_tags {", "tags = {", `env  = "prod"`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("provider doesn't contain %q:\n%s", expected, data)
+		}
+	}
+
+	resources = append(resources, tagged("c", nil))
+	if _, tags := LiftDefaultTags(resources, defaultTags); len(tags) > 0 {
+		t.Errorf("a taggable resource without tags shouldn't share tags, lifted %v", tags)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// StripDefaults removes the optional attributes of Item equal to the defaults the provider
+// plans for the resource, see ProviderWrapper.PlanDefaults. Empty collections are removed when
+// the default is null or empty too, unless AllowEmptyValues keeps them. Attributes forced by
+// AdditionalFields or rules are kept.
+func (r *Resource) StripDefaults(provider *providerwrapper.ProviderWrapper) error {
+	resourceSchema, exist := provider.GetSchema().ResourceTypes[r.InstanceInfo.Type]
+	if !exist {
+		return fmt.Errorf("resource type %s is not supported by provider %s", r.InstanceInfo.Type, r.Provider)
+	}
+	value := r.Value
+	if value == cty.NilVal || value.IsNull() {
+		var err error
+		if value, err = r.InstanceState.AttrsAsObjectValue(resourceSchema.Block.ImpliedType()); err != nil {
+			return err
+		}
+	}
+	defaults, err := provider.PlanDefaults(r.InstanceInfo.Type, value)
+	if err != nil {
+		return err
+	}
+	for name, attribute := range resourceSchema.Block.Attributes {
+		itemValue, exist := r.Item[name]
+		if !exist || attribute.Required || !attribute.Optional || r.isForced(name) {
+			continue
+		}
+		defaultValue := defaults.GetAttr(name)
+		if !defaultValue.IsWhollyKnown() {
+			continue
+		}
+		if isEmptyCollection(itemValue) && !r.isEmptyAllowed(name) && (defaultValue.IsNull() || defaultValue.Type().IsCollectionType() && defaultValue.LengthInt() == 0) {
+			delete(r.Item, name)
+			continue
+		}
+		if !defaultValue.IsNull() && equalsDefault(itemValue, defaultValue) {
+			delete(r.Item, name)
+		}
+	}
+	return nil
+}
+
+// isForced reports whether the attribute is set by AdditionalFields or rules
+func (r *Resource) isForced(name string) bool {
+	if _, exist := r.AdditionalFields[name]; exist {
+		return true
+	}
+	if r.Rules == nil {
+		return false
+	}
+	for path := range r.Rules.Set {
+		if path == name || len(path) > len(name) && path[:len(name)+1] == name+"." {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resource) isEmptyAllowed(name string) bool {
+	for _, pattern := range r.AllowEmptyValues {
+		if pattern != "" && regexp.MustCompile(pattern).MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyCollection(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// equalsDefault compares an Item value with a default, the flatmap converter renders numbers
+// and bools as strings so primitives are compared by their string form and sets regardless of
+// their order
+func equalsDefault(itemValue interface{}, defaultValue cty.Value) bool {
+	data, err := ctyjson.Marshal(defaultValue, defaultValue.Type())
+	if err != nil {
+		return false
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizeValue(itemValue, defaultValue.Type()), normalizeValue(decoded, defaultValue.Type()))
+}
+
+func normalizeValue(value interface{}, ty cty.Type) interface{} {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			normalized[key] = normalizeValue(element, elementType(ty, key))
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, 0, len(v))
+		for _, element := range v {
+			normalized = append(normalized, normalizeValue(element, elementType(ty, "")))
+		}
+		if ty.IsSetType() {
+			sort.Slice(normalized, func(i, j int) bool {
+				return fmt.Sprint(normalized[i]) < fmt.Sprint(normalized[j])
+			})
+		}
+		return normalized
+	}
+	return value
+}
+
+func elementType(ty cty.Type, key string) cty.Type {
+	switch {
+	case ty.IsCollectionType():
+		return ty.ElementType()
+	case ty.IsObjectType() && ty.HasAttribute(key):
+		return ty.AttributeType(key)
+	}
+	return cty.DynamicPseudoType
+}
@@ -35,6 +35,12 @@ const safeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ012345678
 
 var unsafeChars = regexp.MustCompile(`[^0-9A-Za-z_\-]`)
 
+var (
+	ignoreChangesRe   = regexp.MustCompile(`^    ignore_changes\s*=\s*\[`)
+	quotedReferenceRe = regexp.MustCompile(`"([A-Za-z_][0-9A-Za-z_\-]*)"`)
+	heredocStartRe    = regexp.MustCompile(`<<-?([A-Za-z_][0-9A-Za-z_]*)\s*$`)
+)
+
 // make HCL output reproducible by sorting the AST nodes
 func sortHclTree(tree interface{}) {
 	switch t := tree.(type) {
@@ -57,6 +63,8 @@ func sortHclTree(tree interface{}) {
 // sanitizer fixes up an invalid HCL AST, as produced by the HCL parser for JSON
 type astSanitizer struct {
 	sort bool
+	// mapsObjects are the paths of map attributes, see Print
+	mapsObjects map[string]struct{}
 }
 
 // output prints creates b printable HCL output and returns it.
@@ -73,6 +81,7 @@ func (v *astSanitizer) visit(n interface{}) {
 			if index == len(t.Items) {
 				break
 			}
+			v.unflattenMap(t.Items[index])
 			v.visit(t.Items[index])
 			index++
 		}
@@ -94,6 +103,28 @@ func (v *astSanitizer) visit(n interface{}) {
 	}
 }
 
+// unflattenMap undoes the flattening of the JSON parser for map attributes: an object holding
+// only a map, as {"default_tags": {"tags": {...}}}, is parsed as default_tags "tags" {...}
+func (v *astSanitizer) unflattenMap(o *ast.ObjectItem) {
+	if len(o.Keys) < 2 {
+		return
+	}
+	keys := make([]string, 0, len(o.Keys))
+	for _, k := range o.Keys {
+		keys = append(keys, strings.Trim(k.Token.Text, `"`))
+	}
+	for i := range keys {
+		if _, exist := v.mapsObjects[strings.Join(keys[i:], ".")]; !exist {
+			continue
+		}
+		last := len(o.Keys) - 1
+		inner := &ast.ObjectItem{Keys: []*ast.ObjectKey{o.Keys[last]}, Val: o.Val}
+		o.Keys = o.Keys[:last]
+		o.Val = &ast.ObjectType{List: &ast.ObjectList{Items: []*ast.ObjectItem{inner}}}
+		return
+	}
+}
+
 func (v *astSanitizer) visitObjectItem(o *ast.ObjectItem) {
 	for i, k := range o.Keys {
 		if i == 0 {
@@ -185,6 +216,7 @@ func hclPrint(data interface{}, mapsObjects map[string]struct{}, sort bool) ([]b
 	}
 	var sanitizer astSanitizer
 	sanitizer.sort = sort
+	sanitizer.mapsObjects = mapsObjects
 	sanitizer.visit(nodes)
 
 	var b bytes.Buffer
@@ -209,6 +241,7 @@ func hclPrint(data interface{}, mapsObjects map[string]struct{}, sort bool) ([]b
 	formatted = terraform12Adjustments(formatted, mapsObjects)
 	// hack for support terraform 0.13
 	formatted = terraform13Adjustments(formatted)
+	formatted = lifecycleAdjustments(formatted)
 	if err != nil {
 		log.Println("Invalid HCL follows:")
 		for i, line := range strings.Split(s, "\n") {
@@ -272,6 +305,32 @@ func terraform13Adjustments(formatted []byte) []byte {
 	return []byte(s)
 }
 
+// lifecycleAdjustments unquotes the attribute references of ignore_changes in the lifecycle
+// blocks of resources, strings and heredocs of other attributes are left as they are
+func lifecycleAdjustments(formatted []byte) []byte {
+	lines := strings.Split(string(formatted), "\n")
+	heredocEnd := ""
+	inLifecycle, inIgnoreChanges := false, false
+	for i, line := range lines {
+		switch {
+		case heredocEnd != "":
+			if strings.TrimSpace(line) == heredocEnd {
+				heredocEnd = ""
+			}
+		case heredocStartRe.MatchString(line):
+			heredocEnd = heredocStartRe.FindStringSubmatch(line)[1]
+		case line == "  lifecycle {":
+			inLifecycle = true
+		case inLifecycle && line == "  }":
+			inLifecycle = false
+		case inLifecycle && (inIgnoreChanges || ignoreChangesRe.MatchString(line)):
+			lines[i] = quotedReferenceRe.ReplaceAllString(line, "$1")
+			inIgnoreChanges = !strings.Contains(line, "]")
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
 func escapeRune(s string) string {
 	return fmt.Sprintf("-%04X-", s)
 }
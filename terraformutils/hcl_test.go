@@ -52,3 +52,28 @@ func TestPrintResource(t *testing.T) {
 		t.Errorf("failed to parse data %s", string(data))
 	}
 }
+
+func TestPrintResourceLifecycle(t *testing.T) {
+	resource := prepare("b-1", "fake_bucket", map[string]string{}, map[string]interface{}{
+		"description": `set ignore_changes = [`,
+		"domains":     []interface{}{"example", "test"},
+		"policy":      "<<POLICY\n  lifecycle {\n    ignore_changes = [\"y\"]\n  }\nPOLICY",
+		"lifecycle": map[string]interface{}{
+			"ignore_changes":  []interface{}{"policy", "tags"},
+			"prevent_destroy": true,
+		},
+	})
+	data, err := HclPrintResource([]Resource{resource}, map[string]interface{}{}, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"ignore_changes  = [policy, tags]",
+		`domains     = ["example", "test"]`,
+		"    ignore_changes = [\\\"y\\\"]\n",
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("missing %s in\n%s", expected, data)
+		}
+	}
+}
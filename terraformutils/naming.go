@@ -0,0 +1,308 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Naming names resources from a template of placeholders in braces. A placeholder lists
+// alternatives separated by "|", the first one with a value is used: "type" is the resource
+// type, "id" its ID and other alternatives are flatmap keys of its state, e.g.
+// "{type}_{tags.Name|name|id}". Names are normalized to snake_case.
+type Naming struct {
+	parts []namingPart
+}
+
+// namingPart is a literal of the template followed by the alternatives of a placeholder
+type namingPart struct {
+	literal      string
+	alternatives []string
+}
+
+// ParseNaming parses a naming template
+func ParseNaming(template string) (*Naming, error) {
+	naming := &Naming{}
+	hasPlaceholder := false
+	for rest := template; rest != ""; {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			naming.parts = append(naming.parts, namingPart{literal: rest})
+			break
+		}
+		if rest[start] == '}' {
+			return nil, fmt.Errorf("naming %q: unexpected }", template)
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("naming %q: unclosed {", template)
+		}
+		alternatives := strings.Split(rest[start+1:start+end], "|")
+		for i, alternative := range alternatives {
+			alternatives[i] = strings.TrimSpace(alternative)
+			if alternatives[i] == "" || strings.Contains(alternatives[i], "{") {
+				return nil, fmt.Errorf("naming %q: invalid placeholder {%s}", template, rest[start+1:start+end])
+			}
+		}
+		naming.parts = append(naming.parts, namingPart{literal: rest[:start], alternatives: alternatives})
+		hasPlaceholder = true
+		rest = rest[start+end+1:]
+	}
+	if !hasPlaceholder {
+		return nil, fmt.Errorf("naming %q has no placeholder", template)
+	}
+	return naming, nil
+}
+
+// Name renders the name of resource, empty when no placeholder has a value
+func (n *Naming) Name(resource Resource) string {
+	var name strings.Builder
+	for _, part := range n.parts {
+		name.WriteString(part.literal)
+		for _, alternative := range part.alternatives {
+			if value := namingValue(resource, alternative); value != "" {
+				name.WriteString(value)
+				break
+			}
+		}
+	}
+	return SnakeCase(name.String())
+}
+
+func namingValue(resource Resource, key string) string {
+	switch key {
+	case "type":
+		return resource.InstanceInfo.Type
+	case "id":
+		return resource.InstanceState.ID
+	}
+	return resource.InstanceState.Attributes[key]
+}
+
+// SnakeCase normalizes name to a terraform name of lower case letters, digits and underscores,
+// words of camelCase names are separated and names starting with a digit are prefixed by "_"
+func SnakeCase(name string) string {
+	var snake strings.Builder
+	separate := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		isUpper := c >= 'A' && c <= 'Z'
+		isLower := c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+		if !isUpper && !isLower {
+			separate = snake.Len() > 0
+			continue
+		}
+		if isUpper && i > 0 {
+			previous := name[i-1]
+			previousLower := previous >= 'a' && previous <= 'z' || previous >= '0' && previous <= '9'
+			nextLower := i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'
+			if previousLower || previous >= 'A' && previous <= 'Z' && nextLower {
+				separate = snake.Len() > 0
+			}
+		}
+		if separate {
+			snake.WriteByte('_')
+			separate = false
+		}
+		if isUpper {
+			c += 'a' - 'A'
+		}
+		snake.WriteByte(c)
+	}
+	normalized := snake.String()
+	if normalized != "" && normalized[0] >= '0' && normalized[0] <= '9' {
+		normalized = "_" + normalized
+	}
+	return normalized
+}
+
+// referenceRe matches the resource addresses of interpolations, e.g. aws_vpc.tfer--vpc-1
+var referenceRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*\.[A-Za-z0-9_-]+`)
+
+// RenameResources names resources with naming, resources of a type given the same name are
+// suffixed with a hash of their ID so names don't depend on the order of resources. The
+// interpolations of items, e.g. set by PostConvertHook, are changed to the new names.
+func RenameResources(resources []Resource, naming *Naming) {
+	names := make([]string, len(resources))
+	counts := map[string]int{}
+	for i, resource := range resources {
+		name := naming.Name(resource)
+		if name == "" {
+			name = SnakeCase(resource.InstanceState.ID)
+		}
+		if name == "" {
+			name = resource.ResourceName
+		}
+		names[i] = name
+		counts[resource.InstanceInfo.Type+"."+name]++
+	}
+	renamed := map[string]string{}
+	for i := range resources {
+		resource := &resources[i]
+		name := names[i]
+		if counts[resource.InstanceInfo.Type+"."+name] > 1 {
+			name += "_" + idHash(resource.InstanceState.ID)
+		}
+		if name == resource.ResourceName {
+			continue
+		}
+		renamed[resource.InstanceInfo.Type+"."+resource.ResourceName] = resource.InstanceInfo.Type + "." + name
+		resource.ResourceName = name
+		resource.InstanceInfo.Id = resource.InstanceInfo.Type + "." + name
+	}
+	if len(renamed) == 0 {
+		return
+	}
+	for i := range resources {
+		for key, value := range resources[i].Item {
+			resources[i].Item[key] = renameReferences(value, renamed)
+		}
+	}
+}
+
+func idHash(id string) string {
+	sum := sha1.Sum([]byte(id)) //nolint:gosec
+	return hex.EncodeToString(sum[:])[:6]
+}
+
+// renameReferences returns value with the renamed addresses of its interpolations replaced,
+// maps and lists are copied as they may be shared
+func renameReferences(value interface{}, renamed map[string]string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "${") {
+			return v
+		}
+		return referenceRe.ReplaceAllStringFunc(v, func(address string) string {
+			if name, exist := renamed[address]; exist {
+				return name
+			}
+			return address
+		})
+	case map[string]interface{}:
+		updated := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			updated[key] = renameReferences(element, renamed)
+		}
+		return updated
+	case []interface{}:
+		updated := make([]interface{}, len(v))
+		for i, element := range v {
+			updated[i] = renameReferences(element, renamed)
+		}
+		return updated
+	}
+	return value
+}
+
+// Move is a resource renamed since a previous import
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// StateNames reads the names of the resources of a v3 or v4 state by type and ID, keyed by
+// type + "|" + ID
+func StateNames(data []byte) (map[string]string, error) {
+	var state struct {
+		Resources []struct {
+			Mode      string `json:"mode"`
+			Type      string `json:"type"`
+			Name      string `json:"name"`
+			Instances []struct {
+				Attributes     map[string]interface{} `json:"attributes"`
+				AttributesFlat map[string]string      `json:"attributes_flat"`
+			} `json:"instances"`
+		} `json:"resources"`
+		Modules []struct {
+			Resources map[string]struct {
+				Type    string `json:"type"`
+				Primary struct {
+					ID string `json:"id"`
+				} `json:"primary"`
+			} `json:"resources"`
+		} `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for _, resource := range state.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			id, _ := instance.Attributes["id"].(string)
+			if id == "" {
+				id = instance.AttributesFlat["id"]
+			}
+			if id != "" {
+				names[resource.Type+"|"+id] = resource.Name
+			}
+		}
+	}
+	for _, module := range state.Modules {
+		for address, resource := range module.Resources {
+			if strings.HasPrefix(address, "data.") || resource.Primary.ID == "" {
+				continue
+			}
+			names[resource.Type+"|"+resource.Primary.ID] = strings.TrimPrefix(address, resource.Type+".")
+		}
+	}
+	return names, nil
+}
+
+// Moves lists the resources named differently in a previous state, see StateNames
+func Moves(previousNames map[string]string, resources []Resource) []Move {
+	moves := []Move{}
+	for _, resource := range resources {
+		previous, exist := previousNames[resource.InstanceInfo.Type+"|"+resource.InstanceState.ID]
+		if exist && previous != resource.ResourceName {
+			moves = append(moves, Move{
+				From: resource.InstanceInfo.Type + "." + previous,
+				To:   resource.InstanceInfo.Type + "." + resource.ResourceName,
+			})
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		return moves[i].From < moves[j].From
+	})
+	return moves
+}
+
+// PrintMoves renders moves as moved blocks, in HCL or JSON syntax
+func PrintMoves(moves []Move, output string) ([]byte, error) {
+	if output == "json" {
+		data, err := json.MarshalIndent(map[string]interface{}{"moved": moves}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	var hcl strings.Builder
+	for i, move := range moves {
+		if i > 0 {
+			hcl.WriteString("\n")
+		}
+		fmt.Fprintf(&hcl, "moved {\n  from = %s\n  to   = %s\n}\n", move.From, move.To)
+	}
+	return []byte(hcl.String()), nil
+}
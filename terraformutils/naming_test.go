@@ -0,0 +1,118 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Web Server (prod)": "web_server_prod",
+		"myHTTPServer":      "my_http_server",
+		"tfer--sg-0abc":     "tfer_sg_0abc",
+		"3f9a":              "_3f9a",
+		"--":                "",
+	} {
+		if snake := SnakeCase(name); snake != expected {
+			t.Errorf("SnakeCase(%q) = %q, expected %q", name, snake, expected)
+		}
+	}
+}
+
+func TestParseNamingErrors(t *testing.T) {
+	for _, template := range []string{"static", "{type", "type}", "{type}_{}", "{tags.Name|}"} {
+		if _, err := ParseNaming(template); err == nil {
+			t.Errorf("ParseNaming(%q) succeeded", template)
+		}
+	}
+}
+
+func TestRenameResources(t *testing.T) {
+	naming, err := ParseNaming("{tags.Name|name|id}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource := func(id, resourceType string, attributes map[string]string, item map[string]interface{}) Resource {
+		resource := NewResource(id, id, resourceType, "aws", attributes, nil, nil)
+		resource.Item = item
+		return resource
+	}
+	resources := []Resource{
+		resource("vpc-1", "aws_vpc", map[string]string{"tags.Name": "Main VPC"}, map[string]interface{}{}),
+		resource("subnet-1", "aws_subnet", map[string]string{"tags.Name": "public"}, map[string]interface{}{
+			"vpc_id": "${aws_vpc.tfer--vpc-1.id}",
+			"tags":   map[string]interface{}{"Name": "public"},
+		}),
+		resource("subnet-2", "aws_subnet", map[string]string{"tags.Name": "public"}, map[string]interface{}{
+			"vpc_id": "${data.aws_vpc.tfer--vpc-1.id}",
+		}),
+		resource("sg-1", "aws_security_group", map[string]string{}, map[string]interface{}{}),
+	}
+	RenameResources(resources, naming)
+
+	names := []string{}
+	for _, resource := range resources {
+		names = append(names, resource.InstanceInfo.Id)
+	}
+	expectedNames := []string{
+		"aws_vpc.main_vpc",
+		"aws_subnet.public_" + idHash("subnet-1"),
+		"aws_subnet.public_" + idHash("subnet-2"),
+		"aws_security_group.sg_1",
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("unexpected names %v, expected %v", names, expectedNames)
+	}
+	if vpcID := resources[1].Item["vpc_id"]; vpcID != "${aws_vpc.main_vpc.id}" {
+		t.Errorf("reference not renamed: %v", vpcID)
+	}
+	if vpcID := resources[2].Item["vpc_id"]; vpcID != "${data.aws_vpc.tfer--vpc-1.id}" {
+		t.Errorf("data source reference renamed: %v", vpcID)
+	}
+
+	// renaming again keeps the names
+	RenameResources(resources, naming)
+	if resources[0].ResourceName != "main_vpc" || resources[1].ResourceName != "public_"+idHash("subnet-1") {
+		t.Errorf("names changed when renaming again: %s, %s", resources[0].ResourceName, resources[1].ResourceName)
+	}
+}
+
+func TestMoves(t *testing.T) {
+	vpc := NewSimpleResource("vpc-1", "vpc-1", "aws_vpc", "aws", nil)
+	vpc.InstanceState.Attributes["id"] = "vpc-1"
+	previousState, err := PrintTfStateV4([]Resource{vpc}, "registry.terraform.io/hashicorp/aws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousNames, err := StateNames(previousState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc.ResourceName = "main_vpc"
+	moves := Moves(previousNames, []Resource{vpc})
+	if expected := []Move{{From: "aws_vpc.tfer--vpc-1", To: "aws_vpc.main_vpc"}}; !reflect.DeepEqual(moves, expected) {
+		t.Fatalf("unexpected moves %v, expected %v", moves, expected)
+	}
+	hcl, err := PrintMoves(moves, "hcl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "moved {\n  from = aws_vpc.tfer--vpc-1\n  to   = aws_vpc.main_vpc\n}\n"; string(hcl) != expected {
+		t.Errorf("unexpected moved blocks:\n%s", hcl)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Parameter is a literal of the environment imported, e.g. an account ID, a project or a
+// region, replaced by a reference so the configuration can be reused in another environment
+type Parameter struct {
+	// Value is the literal, it is replaced where it isn't part of a longer name
+	Value string
+	// Reference replaces Value in resources, e.g. "${data.aws_region.current.name}"
+	Reference string
+	// ProviderReference replaces Value in the provider configuration, which can't use data
+	// sources, Value is kept when it is empty
+	ProviderReference string
+	// Variables are the input variables the references use, with their defaults
+	Variables map[string]string
+	// DataSources are the types of the data sources named "current" the references use
+	DataSources []string
+}
+
+// ProviderWithParameters is a provider whose configuration depends on literals of the environment
+type ProviderWithParameters interface {
+	GetParameters(ctx context.Context) ([]Parameter, error)
+}
+
+// Parameterize replaces the values of parameters with their references in the strings of the
+// items of resources and returns the resources with the parameters used. Resources are copied,
+// a result can be parameterized again for another output.
+func Parameterize(resources []Resource, parameters []Parameter) ([]Resource, []Parameter) {
+	parameters = sortParameters(parameters)
+	used := map[int]bool{}
+	parameterized := make([]Resource, 0, len(resources))
+	for _, resource := range resources {
+		item := make(map[string]interface{}, len(resource.Item))
+		for key, value := range resource.Item {
+			item[key] = parameterizeValue(value, parameters, used, false)
+		}
+		resource.Item = item
+		parameterized = append(parameterized, resource)
+	}
+	return parameterized, usedParameters(parameters, used)
+}
+
+// ParameterizeProviderData replaces the values of parameters having a ProviderReference in the
+// provider blocks of providerData and returns the parameters used. providerData is changed.
+func ParameterizeProviderData(providerData map[string]interface{}, parameters []Parameter) []Parameter {
+	parameters = sortParameters(parameters)
+	used := map[int]bool{}
+	if providers, ok := providerData["provider"].(map[string]interface{}); ok {
+		for name, config := range providers {
+			providers[name] = parameterizeValue(config, parameters, used, true)
+		}
+	}
+	return usedParameters(parameters, used)
+}
+
+// AddParameterDeclarations declares the variables and data sources of parameters in variables,
+// the content of variables.tf
+func AddParameterDeclarations(variables map[string]map[string]map[string]interface{}, parameters []Parameter) {
+	for _, parameter := range parameters {
+		for name, defaultValue := range parameter.Variables {
+			if variables["variable"] == nil {
+				variables["variable"] = map[string]map[string]interface{}{}
+			}
+			variables["variable"][name] = map[string]interface{}{
+				"default": defaultValue,
+			}
+		}
+		for _, dataSource := range parameter.DataSources {
+			if variables["data"] == nil {
+				variables["data"] = map[string]map[string]interface{}{}
+			}
+			variables["data"][dataSource] = map[string]interface{}{
+				"current": map[string]interface{}{},
+			}
+		}
+	}
+}
+
+// sortParameters orders parameters by decreasing length of value, e.g. zones before regions
+func sortParameters(parameters []Parameter) []Parameter {
+	sorted := make([]Parameter, 0, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Value != "" {
+			sorted = append(sorted, parameter)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Value) > len(sorted[j].Value)
+	})
+	return sorted
+}
+
+func usedParameters(parameters []Parameter, used map[int]bool) []Parameter {
+	var parametersUsed []Parameter
+	for i, parameter := range parameters {
+		if used[i] {
+			parametersUsed = append(parametersUsed, parameter)
+		}
+	}
+	return parametersUsed
+}
+
+// parameterizeValue returns value with parameters replaced in its strings, maps and lists are
+// copied as they may be shared
+func parameterizeValue(value interface{}, parameters []Parameter, used map[int]bool, isProvider bool) interface{} {
+	switch v := value.(type) {
+	case string:
+		for i, parameter := range parameters {
+			reference := parameter.Reference
+			if isProvider {
+				reference = parameter.ProviderReference
+			}
+			if reference == "" {
+				continue
+			}
+			if replaced := replaceLiteral(v, parameter.Value, reference); replaced != v {
+				v = replaced
+				used[i] = true
+			}
+		}
+		return v
+	case map[string]interface{}:
+		parameterized := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			parameterized[key] = parameterizeValue(element, parameters, used, isProvider)
+		}
+		return parameterized
+	case []interface{}:
+		parameterized := make([]interface{}, len(v))
+		for i, element := range v {
+			parameterized[i] = parameterizeValue(element, parameters, used, isProvider)
+		}
+		return parameterized
+	}
+	return value
+}
+
+// replaceLiteral replaces the occurrences of literal in s that are neither part of a longer
+// name, e.g. the region of a zone, nor inside an interpolation
+func replaceLiteral(s, literal, reference string) string {
+	var replaced strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "${") {
+			end := strings.Index(s[i:], "}")
+			if end < 0 {
+				end = len(s) - i - 1
+			}
+			replaced.WriteString(s[i : i+end+1])
+			i += end + 1
+			continue
+		}
+		if strings.HasPrefix(s[i:], literal) && (i == 0 || !isNameChar(s[i-1])) && (i+len(literal) == len(s) || !isNameChar(s[i+len(literal)])) {
+			replaced.WriteString(reference)
+			i += len(literal)
+			continue
+		}
+		replaced.WriteByte(s[i])
+		i++
+	}
+	return replaced.String()
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
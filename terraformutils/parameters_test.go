@@ -0,0 +1,108 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParameterize(t *testing.T) {
+	parameters := []Parameter{
+		{
+			Value:       "123456789012",
+			Reference:   "${data.aws_caller_identity.current.account_id}",
+			DataSources: []string{"aws_caller_identity"},
+		},
+		{
+			Value:             "eu-west-1",
+			Reference:         "${data.aws_region.current.name}",
+			ProviderReference: "${var.region}",
+			Variables:         map[string]string{"region": "eu-west-1"},
+			DataSources:       []string{"aws_region"},
+		},
+		{
+			Value:     "eu-west-1a",
+			Reference: "${var.region}a",
+			Variables: map[string]string{"region": "eu-west-1"},
+		},
+	}
+	role := NewSimpleResource("role", "role", "aws_iam_role", "aws", nil)
+	role.Item = map[string]interface{}{
+		"arn":  "arn:aws:iam::123456789012:role/deploy",
+		"name": "deploy-123456789012x",
+		"policy_arns": []interface{}{
+			"arn:aws:iam::aws:policy/ReadOnlyAccess",
+			"arn:aws:sns:eu-west-1:123456789012:alerts",
+		},
+	}
+	subnet := NewSimpleResource("subnet", "subnet", "aws_subnet", "aws", nil)
+	subnet.Item = map[string]interface{}{
+		"availability_zone": "eu-west-1a",
+		"vpc_id":            "${data.terraform_remote_state.vpc.outputs.aws_vpc_eu-west-1_id}",
+		"tags":              map[string]interface{}{"Name": "eu-west-1-public"},
+	}
+	resources := []Resource{role, subnet}
+
+	parameterized, used := Parameterize(resources, parameters)
+	expectedItems := []map[string]interface{}{
+		{
+			"arn":  "arn:aws:iam::${data.aws_caller_identity.current.account_id}:role/deploy",
+			"name": "deploy-123456789012x",
+			"policy_arns": []interface{}{
+				"arn:aws:iam::aws:policy/ReadOnlyAccess",
+				"arn:aws:sns:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:alerts",
+			},
+		},
+		{
+			"availability_zone": "${var.region}a",
+			"vpc_id":            "${data.terraform_remote_state.vpc.outputs.aws_vpc_eu-west-1_id}",
+			"tags":              map[string]interface{}{"Name": "eu-west-1-public"},
+		},
+	}
+	for i, resource := range parameterized {
+		if !reflect.DeepEqual(resource.Item, expectedItems[i]) {
+			t.Errorf("unexpected item %v, expected %v", resource.Item, expectedItems[i])
+		}
+	}
+	if len(used) != 3 {
+		t.Errorf("expected every parameter to be used, got %v", used)
+	}
+	if resources[0].Item["arn"] != "arn:aws:iam::123456789012:role/deploy" {
+		t.Error("parameterizing changed the resources")
+	}
+
+	providerData := map[string]interface{}{"provider": map[string]interface{}{"aws": map[string]interface{}{"region": "eu-west-1"}}}
+	usedByProvider := ParameterizeProviderData(providerData, parameters)
+	if region := providerData["provider"].(map[string]interface{})["aws"].(map[string]interface{})["region"]; region != "${var.region}" {
+		t.Errorf("unexpected provider region %v", region)
+	}
+	if len(usedByProvider) != 1 || usedByProvider[0].Value != "eu-west-1" {
+		t.Errorf("unexpected parameters used by the provider %v", usedByProvider)
+	}
+
+	variables := map[string]map[string]map[string]interface{}{}
+	AddParameterDeclarations(variables, used)
+	data, err := Print(variables, map[string]struct{}{}, "hcl", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{`data "aws_caller_identity" "current"`, `data "aws_region" "current"`, `variable "region"`, `default = "eu-west-1"`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("variables miss %s:\n%s", expected, data)
+		}
+	}
+}
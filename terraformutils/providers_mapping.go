@@ -146,6 +146,25 @@ func (p *ProvidersMapping) GetResourcesByService() map[string][]Resource {
 	return mapping
 }
 
+// ApplyRules adds rules to every resource, call it before converting them
+func (p *ProvidersMapping) ApplyRules(rules Rules) {
+	if len(rules) == 0 {
+		return
+	}
+	for resource := range p.Resources {
+		resource.ApplyRules(rules)
+	}
+}
+
+// StripDefaults removes the attributes equal to their provider default from every resource
+func (p *ProvidersMapping) StripDefaults(providerWrapper *providerwrapper.ProviderWrapper) {
+	for resource := range p.Resources {
+		if err := resource.StripDefaults(providerWrapper); err != nil {
+			log.Printf("WARN: can't strip the defaults of %s: %s", resource.InstanceInfo.Id, err)
+		}
+	}
+}
+
 func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.ProviderWrapper) {
 	for resource := range p.Resources {
 		err := resource.ConvertTFstate(providerWrapper)
@@ -153,7 +172,21 @@ func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.Prov
 			log.Printf("failed to convert resources %s because of error %s", resource.InstanceInfo.Id, err)
 		}
 	}
+	p.setServiceResources()
+}
 
+// ConvertValues is ConvertTFStates converting resources from their refreshed value
+func (p *ProvidersMapping) ConvertValues(providerWrapper *providerwrapper.ProviderWrapper) {
+	for resource := range p.Resources {
+		err := resource.ConvertValue(providerWrapper)
+		if err != nil {
+			log.Printf("failed to convert resources %s because of error %s", resource.InstanceInfo.Id, err)
+		}
+	}
+	p.setServiceResources()
+}
+
+func (p *ProvidersMapping) setServiceResources() {
 	resourcesGroupsByProviders := map[ProviderGenerator][]Resource{}
 	for resource := range p.Resources {
 		provider := p.resourceToProvider[resource]
@@ -166,7 +199,6 @@ func (p *ProvidersMapping) ConvertTFStates(providerWrapper *providerwrapper.Prov
 	for provider := range p.Providers {
 		provider.GetService().SetResources(resourcesGroupsByProviders[provider])
 	}
-
 }
 
 func (p *ProvidersMapping) CleanupProviders() {
@@ -0,0 +1,100 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/plans/objchange"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// PlanDefaults asks the provider to plan the creation of a resource configured with the
+// required attributes of value only, the planned attributes that are known are the defaults
+// the provider sets for the resource. Nothing is created, planning is a dry run.
+func (p *ProviderWrapper) PlanDefaults(resourceType string, value cty.Value) (cty.Value, error) {
+	resourceSchema, exist := p.GetSchema().ResourceTypes[resourceType]
+	if !exist {
+		return cty.NilVal, fmt.Errorf("resource type %s is not supported by provider %s", resourceType, p.providerName)
+	}
+	block := resourceSchema.Block
+	config := minimalConfig(block, value)
+	prior := cty.NullVal(block.ImpliedType())
+
+	p.isolation.RLock()
+	defer p.isolation.RUnlock()
+	resp := p.plugin().PlanResourceChange(providers.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       prior,
+		Config:           config,
+		ProposedNewState: objchange.ProposedNewObject(block, prior, config),
+	})
+	if resp.Diagnostics.HasErrors() {
+		return cty.NilVal, resp.Diagnostics.Err()
+	}
+	if resp.PlannedState.IsNull() {
+		return cty.NilVal, fmt.Errorf("provider %s planned no %s", p.providerName, resourceType)
+	}
+	return resp.PlannedState, nil
+}
+
+// minimalConfig keeps the required attributes of value, and of the blocks that can't be empty
+func minimalConfig(block *configschema.Block, value cty.Value) cty.Value {
+	attributes := map[string]cty.Value{}
+	known := value != cty.NilVal && !value.IsNull() && value.IsKnown()
+	for name, attribute := range block.Attributes {
+		if attribute.Required && known {
+			attributes[name] = value.GetAttr(name)
+		} else {
+			attributes[name] = cty.NullVal(attribute.Type)
+		}
+	}
+	for name, nested := range block.BlockTypes {
+		ty := nested.Block.ImpliedType()
+		var blockValue cty.Value
+		if known {
+			blockValue = value.GetAttr(name)
+		}
+		switch nested.Nesting {
+		case configschema.NestingSingle:
+			attributes[name] = cty.NullVal(ty)
+		case configschema.NestingGroup:
+			attributes[name] = minimalConfig(&nested.Block, blockValue)
+		case configschema.NestingList, configschema.NestingSet:
+			elements := []cty.Value{}
+			if nested.MinItems > 0 && blockValue != cty.NilVal && blockValue.IsKnown() && !blockValue.IsNull() {
+				for it := blockValue.ElementIterator(); it.Next(); {
+					_, element := it.Element()
+					elements = append(elements, minimalConfig(&nested.Block, element))
+				}
+			}
+			switch {
+			case len(elements) == 0 && nested.Nesting == configschema.NestingList:
+				attributes[name] = cty.ListValEmpty(ty)
+			case len(elements) == 0:
+				attributes[name] = cty.SetValEmpty(ty)
+			case nested.Nesting == configschema.NestingList:
+				attributes[name] = cty.ListVal(elements)
+			default:
+				attributes[name] = cty.SetVal(elements)
+			}
+		case configschema.NestingMap:
+			attributes[name] = cty.MapValEmpty(ty)
+		}
+	}
+	return cty.ObjectVal(attributes)
+}
@@ -0,0 +1,548 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/tfdiags"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"github.com/zclconf/go-cty/cty/msgpack"
+	"google.golang.org/grpc"
+
+	proto "github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/tfplugin6"
+)
+
+// GRPCProviderV6Plugin implements plugin.GRPCPlugin for providers speaking plugin protocol 6
+type GRPCProviderV6Plugin struct {
+	plugin.Plugin
+}
+
+func (p *GRPCProviderV6Plugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCProviderV6{
+		client: proto.NewProviderClient(c),
+		ctx:    ctx,
+	}, nil
+}
+
+func (p *GRPCProviderV6Plugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	return errors.New("terraformer only implements the client side of plugin protocol 6")
+}
+
+// GRPCProviderV6 translates providers.Interface calls into plugin protocol 6 requests,
+// so protocol 5 and protocol 6 providers are used the same way by ProviderWrapper
+type GRPCProviderV6 struct {
+	client proto.ProviderClient
+	ctx    context.Context
+
+	mu      sync.Mutex
+	schemas providers.GetSchemaResponse
+}
+
+func (p *GRPCProviderV6) GetSchema() (resp providers.GetSchemaResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.schemas.Provider.Block != nil {
+		return p.schemas
+	}
+
+	resp.ResourceTypes = make(map[string]providers.Schema)
+	resp.DataSources = make(map[string]providers.Schema)
+
+	// same limit as the protocol 5 client, big providers exceed the 4MB grpc default
+	const maxRecvSize = 64 << 20
+	protoResp, err := p.client.GetProviderSchema(p.ctx, new(proto.GetProviderSchema_Request), grpc.MaxRecvMsgSizeCallOption{MaxRecvMsgSize: maxRecvSize})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	if protoResp.Provider == nil {
+		resp.Diagnostics = resp.Diagnostics.Append(errors.New("missing provider schema"))
+		return resp
+	}
+
+	if resp.Provider, err = protoV6ToProviderSchema(protoResp.Provider); err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("provider schema: %w", err))
+	}
+	for name, res := range protoResp.ResourceSchemas {
+		if resp.ResourceTypes[name], err = protoV6ToProviderSchema(res); err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("schema of resource %s: %w", name, err))
+		}
+	}
+	for name, data := range protoResp.DataSourceSchemas {
+		if resp.DataSources[name], err = protoV6ToProviderSchema(data); err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("schema of data source %s: %w", name, err))
+		}
+	}
+	if !resp.Diagnostics.HasErrors() {
+		p.schemas = resp
+	}
+	return resp
+}
+
+func (p *GRPCProviderV6) resourceType(typeName string) (cty.Type, error) {
+	schema, ok := p.GetSchema().ResourceTypes[typeName]
+	if !ok {
+		return cty.NilType, errors.New("unknown resource type " + typeName)
+	}
+	return schema.Block.ImpliedType(), nil
+}
+
+func (p *GRPCProviderV6) dataSourceType(typeName string) (cty.Type, error) {
+	schema, ok := p.GetSchema().DataSources[typeName]
+	if !ok {
+		return cty.NilType, errors.New("unknown data source " + typeName)
+	}
+	return schema.Block.ImpliedType(), nil
+}
+
+func (p *GRPCProviderV6) PrepareProviderConfig(r providers.PrepareProviderConfigRequest) (resp providers.PrepareProviderConfigResponse) {
+	config, err := encodeDynamicValue(r.Config, p.GetSchema().Provider.Block.ImpliedType())
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ValidateProviderConfig(p.ctx, &proto.ValidateProviderConfig_Request{Config: config})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	// protocol 6 dropped config preparation, the config is used as is
+	resp.PreparedConfig = r.Config
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	return resp
+}
+
+func (p *GRPCProviderV6) ValidateResourceTypeConfig(r providers.ValidateResourceTypeConfigRequest) (resp providers.ValidateResourceTypeConfigResponse) {
+	ty, err := p.resourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	config, err := encodeDynamicValue(r.Config, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ValidateResourceConfig(p.ctx, &proto.ValidateResourceConfig_Request{
+		TypeName: r.TypeName,
+		Config:   config,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	return resp
+}
+
+func (p *GRPCProviderV6) ValidateDataSourceConfig(r providers.ValidateDataSourceConfigRequest) (resp providers.ValidateDataSourceConfigResponse) {
+	ty, err := p.dataSourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	config, err := encodeDynamicValue(r.Config, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ValidateDataResourceConfig(p.ctx, &proto.ValidateDataResourceConfig_Request{
+		TypeName: r.TypeName,
+		Config:   config,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	return resp
+}
+
+func (p *GRPCProviderV6) UpgradeResourceState(r providers.UpgradeResourceStateRequest) (resp providers.UpgradeResourceStateResponse) {
+	ty, err := p.resourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.UpgradeResourceState(p.ctx, &proto.UpgradeResourceState_Request{
+		TypeName: r.TypeName,
+		Version:  int64(r.Version),
+		RawState: &proto.RawState{
+			Json:    r.RawStateJSON,
+			Flatmap: r.RawStateFlatmap,
+		},
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	resp.UpgradedState, err = decodeDynamicValue(protoResp.UpgradedState, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+	}
+	return resp
+}
+
+func (p *GRPCProviderV6) Configure(r providers.ConfigureRequest) (resp providers.ConfigureResponse) {
+	config, err := encodeDynamicValue(r.Config, p.GetSchema().Provider.Block.ImpliedType())
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ConfigureProvider(p.ctx, &proto.ConfigureProvider_Request{
+		TerraformVersion: r.TerraformVersion,
+		Config:           config,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	return resp
+}
+
+func (p *GRPCProviderV6) Stop() error {
+	resp, err := p.client.StopProvider(p.ctx, new(proto.StopProvider_Request))
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+func (p *GRPCProviderV6) ReadResource(r providers.ReadResourceRequest) (resp providers.ReadResourceResponse) {
+	ty, err := p.resourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	state, err := encodeDynamicValue(r.PriorState, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ReadResource(p.ctx, &proto.ReadResource_Request{
+		TypeName:     r.TypeName,
+		CurrentState: state,
+		Private:      r.Private,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	resp.NewState, err = decodeDynamicValue(protoResp.NewState, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+	}
+	resp.Private = protoResp.Private
+	return resp
+}
+
+func (p *GRPCProviderV6) PlanResourceChange(r providers.PlanResourceChangeRequest) (resp providers.PlanResourceChangeResponse) {
+	ty, err := p.resourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	values := []*proto.DynamicValue{}
+	for _, v := range []cty.Value{r.PriorState, r.Config, r.ProposedNewState} {
+		value, err := encodeDynamicValue(v, ty)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+			return resp
+		}
+		values = append(values, value)
+	}
+	protoResp, err := p.client.PlanResourceChange(p.ctx, &proto.PlanResourceChange_Request{
+		TypeName:         r.TypeName,
+		PriorState:       values[0],
+		Config:           values[1],
+		ProposedNewState: values[2],
+		PriorPrivate:     r.PriorPrivate,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	resp.PlannedState, err = decodeDynamicValue(protoResp.PlannedState, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	for _, path := range protoResp.RequiresReplace {
+		resp.RequiresReplace = append(resp.RequiresReplace, protoV6ToPath(path))
+	}
+	resp.PlannedPrivate = protoResp.PlannedPrivate
+	resp.LegacyTypeSystem = protoResp.LegacyTypeSystem
+	return resp
+}
+
+func (p *GRPCProviderV6) ApplyResourceChange(r providers.ApplyResourceChangeRequest) (resp providers.ApplyResourceChangeResponse) {
+	ty, err := p.resourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	values := []*proto.DynamicValue{}
+	for _, v := range []cty.Value{r.PriorState, r.PlannedState, r.Config} {
+		value, err := encodeDynamicValue(v, ty)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+			return resp
+		}
+		values = append(values, value)
+	}
+	protoResp, err := p.client.ApplyResourceChange(p.ctx, &proto.ApplyResourceChange_Request{
+		TypeName:       r.TypeName,
+		PriorState:     values[0],
+		PlannedState:   values[1],
+		Config:         values[2],
+		PlannedPrivate: r.PlannedPrivate,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	resp.NewState, err = decodeDynamicValue(protoResp.NewState, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Private = protoResp.Private
+	resp.LegacyTypeSystem = protoResp.LegacyTypeSystem
+	return resp
+}
+
+func (p *GRPCProviderV6) ImportResourceState(r providers.ImportResourceStateRequest) (resp providers.ImportResourceStateResponse) {
+	protoResp, err := p.client.ImportResourceState(p.ctx, &proto.ImportResourceState_Request{
+		TypeName: r.TypeName,
+		Id:       r.ID,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+
+	for _, imported := range protoResp.ImportedResources {
+		ty, err := p.resourceType(imported.TypeName)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+			return resp
+		}
+		state, err := decodeDynamicValue(imported.State, ty)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+			return resp
+		}
+		resp.ImportedResources = append(resp.ImportedResources, providers.ImportedResource{
+			TypeName: imported.TypeName,
+			State:    state,
+			Private:  imported.Private,
+		})
+	}
+	return resp
+}
+
+func (p *GRPCProviderV6) ReadDataSource(r providers.ReadDataSourceRequest) (resp providers.ReadDataSourceResponse) {
+	ty, err := p.dataSourceType(r.TypeName)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	config, err := encodeDynamicValue(r.Config, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	protoResp, err := p.client.ReadDataSource(p.ctx, &proto.ReadDataSource_Request{
+		TypeName: r.TypeName,
+		Config:   config,
+	})
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(protoV6ToDiagnostics(protoResp.Diagnostics))
+	resp.State, err = decodeDynamicValue(protoResp.State, ty)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+	}
+	return resp
+}
+
+// Close is a no-op, the plugin process is stopped by ProviderWrapper.Kill
+func (p *GRPCProviderV6) Close() error {
+	return nil
+}
+
+func encodeDynamicValue(v cty.Value, ty cty.Type) (*proto.DynamicValue, error) {
+	mp, err := msgpack.Marshal(v, ty)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DynamicValue{Msgpack: mp}, nil
+}
+
+// decodeDynamicValue reads msgpack or, for providers answering with it, JSON encoded values
+func decodeDynamicValue(v *proto.DynamicValue, ty cty.Type) (cty.Value, error) {
+	if v == nil {
+		return cty.NullVal(ty), nil
+	}
+	if len(v.Json) > 0 {
+		return ctyjson.Unmarshal(v.Json, ty)
+	}
+	return msgpack.Unmarshal(v.Msgpack, ty)
+}
+
+func protoV6ToProviderSchema(s *proto.Schema) (providers.Schema, error) {
+	block, err := protoV6ToConfigSchema(s.Block)
+	if err != nil {
+		return providers.Schema{}, err
+	}
+	return providers.Schema{
+		Version: s.Version,
+		Block:   block,
+	}, nil
+}
+
+func protoV6ToConfigSchema(b *proto.Schema_Block) (*configschema.Block, error) {
+	block := &configschema.Block{
+		Attributes: make(map[string]*configschema.Attribute),
+		BlockTypes: make(map[string]*configschema.NestedBlock),
+	}
+	if b == nil {
+		return block, nil
+	}
+	for _, a := range b.Attributes {
+		ty, err := protoV6AttributeType(a)
+		if err != nil {
+			return nil, err
+		}
+		block.Attributes[a.Name] = &configschema.Attribute{
+			Type:        ty,
+			Description: a.Description,
+			Required:    a.Required,
+			Optional:    a.Optional,
+			Computed:    a.Computed,
+			Sensitive:   a.Sensitive,
+		}
+	}
+	for _, nb := range b.BlockTypes {
+		var nesting configschema.NestingMode
+		switch nb.Nesting {
+		case proto.Schema_NestedBlock_SINGLE:
+			nesting = configschema.NestingSingle
+		case proto.Schema_NestedBlock_GROUP:
+			nesting = configschema.NestingGroup
+		case proto.Schema_NestedBlock_LIST:
+			nesting = configschema.NestingList
+		case proto.Schema_NestedBlock_MAP:
+			nesting = configschema.NestingMap
+		case proto.Schema_NestedBlock_SET:
+			nesting = configschema.NestingSet
+		}
+		nested, err := protoV6ToConfigSchema(nb.Block)
+		if err != nil {
+			return nil, fmt.Errorf("block %s: %w", nb.TypeName, err)
+		}
+		block.BlockTypes[nb.TypeName] = &configschema.NestedBlock{
+			Block:    *nested,
+			Nesting:  nesting,
+			MinItems: int(nb.MinItems),
+			MaxItems: int(nb.MaxItems),
+		}
+	}
+	return block, nil
+}
+
+// protoV6AttributeType returns the cty type of an attribute, protocol 6 nested attributes
+// are flattened into object collections since configschema predates them
+func protoV6AttributeType(a *proto.Schema_Attribute) (cty.Type, error) {
+	if a.NestedType == nil {
+		var ty cty.Type
+		if err := json.Unmarshal(a.Type, &ty); err != nil {
+			return cty.NilType, fmt.Errorf("type of attribute %s: %w", a.Name, err)
+		}
+		return ty, nil
+	}
+	attributes := map[string]cty.Type{}
+	for _, nested := range a.NestedType.Attributes {
+		ty, err := protoV6AttributeType(nested)
+		if err != nil {
+			return cty.NilType, fmt.Errorf("attribute %s: %w", a.Name, err)
+		}
+		attributes[nested.Name] = ty
+	}
+	object := cty.Object(attributes)
+	switch a.NestedType.Nesting {
+	case proto.Schema_Object_LIST:
+		return cty.List(object), nil
+	case proto.Schema_Object_SET:
+		return cty.Set(object), nil
+	case proto.Schema_Object_MAP:
+		return cty.Map(object), nil
+	default:
+		return object, nil
+	}
+}
+
+func protoV6ToDiagnostics(ds []*proto.Diagnostic) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, d := range ds {
+		severity := tfdiags.Error
+		if d.Severity == proto.Diagnostic_WARNING {
+			severity = tfdiags.Warning
+		}
+		if d.Attribute != nil {
+			diags = diags.Append(tfdiags.AttributeValue(severity, d.Summary, d.Detail, protoV6ToPath(d.Attribute)))
+		} else {
+			diags = diags.Append(tfdiags.WholeContainingBody(severity, d.Summary, d.Detail))
+		}
+	}
+	return diags
+}
+
+func protoV6ToPath(ap *proto.AttributePath) cty.Path {
+	var p cty.Path
+	for _, step := range ap.Steps {
+		switch selector := step.Selector.(type) {
+		case *proto.AttributePath_Step_AttributeName:
+			p = p.GetAttr(selector.AttributeName)
+		case *proto.AttributePath_Step_ElementKeyString:
+			p = p.Index(cty.StringVal(selector.ElementKeyString))
+		case *proto.AttributePath_Step_ElementKeyInt:
+			p = p.Index(cty.NumberIntVal(selector.ElementKeyInt))
+		}
+	}
+	return p
+}
@@ -0,0 +1,143 @@
+package providerwrapper //nolint
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+
+	proto "github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/tfplugin6"
+)
+
+// newV6TestProvider builds testdata/terraform-provider-v6test into a fake TF_DATA_DIR and starts it
+func newV6TestProvider(t *testing.T) *ProviderWrapper {
+	dataDir := t.TempDir()
+	pluginDir := filepath.Join(dataDir, "providers", "registry.terraform.io", "terraformer", "v6test", "0.0.1", runtime.GOOS+"_"+runtime.GOARCH)
+	build := exec.Command("go", "build", "-o", filepath.Join(pluginDir, "terraform-provider-v6test_v0.0.1"), "./testdata/terraform-provider-v6test")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		t.Fatalf("can't build v6 test provider: %v", err)
+	}
+	t.Setenv("TF_DATA_DIR", dataDir)
+
+	provider, err := NewProviderWrapper("v6test", cty.ObjectVal(map[string]cty.Value{
+		"endpoint": cty.StringVal("https://example.com"),
+	}), false, map[string]int{"retryCount": 1, "retrySleepMs": 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(provider.Kill)
+	return provider
+}
+
+func TestProtocolV6Provider(t *testing.T) {
+	provider := newV6TestProvider(t)
+
+	if _, ok := provider.Provider.(*GRPCProviderV6); !ok {
+		t.Fatalf("expected protocol 6 client, got %T", provider.Provider)
+	}
+	schema := provider.GetSchema()
+	if schema.Diagnostics.HasErrors() {
+		t.Fatal(schema.Diagnostics.Err())
+	}
+	settings := schema.ResourceTypes["v6test_thing"].Block.Attributes["settings"]
+	if expected := cty.Object(map[string]cty.Type{"enabled": cty.Bool}); settings == nil || !settings.Type.Equals(expected) {
+		t.Fatalf("nested attribute settings was not mapped to %#v: %#v", expected, settings)
+	}
+
+	t.Run("read", func(t *testing.T) {
+		state, err := provider.Refresh(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--a"}, &terraform.InstanceState{
+			ID:         "a",
+			Attributes: map[string]string{"id": "a", "name": "a"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Attributes["name"] != "a" || state.Attributes["settings.enabled"] != "true" {
+			t.Errorf("unexpected refreshed attributes %v", state.Attributes)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		state, err := provider.Refresh(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--b"}, &terraform.InstanceState{
+			ID:         "b",
+			Attributes: map[string]string{"id": "b"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Attributes["name"] != "https://example.com/b" {
+			t.Errorf("unexpected imported attributes %v", state.Attributes)
+		}
+	})
+
+	t.Run("upgrade", func(t *testing.T) {
+		resp := provider.Provider.UpgradeResourceState(providers.UpgradeResourceStateRequest{
+			TypeName:     "v6test_thing",
+			Version:      0,
+			RawStateJSON: []byte(`{"id":"c","name":"c","settings":{"enabled":false}}`),
+		})
+		if resp.Diagnostics.HasErrors() {
+			t.Fatal(resp.Diagnostics.Err())
+		}
+		if resp.UpgradedState.GetAttr("settings").GetAttr("enabled").True() {
+			t.Errorf("unexpected upgraded state %#v", resp.UpgradedState)
+		}
+	})
+	t.Run("import upgrade read", func(t *testing.T) {
+		state, err := provider.RefreshWithStrategy(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--d"}, &terraform.InstanceState{
+			ID:         "d",
+			Attributes: map[string]string{"id": "d"},
+		}, RefreshImportUpgradeRead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Attributes["name"] != "https://example.com/d" || state.Attributes["settings.enabled"] != "true" {
+			t.Errorf("ID only resource was not imported then read %v", state.Attributes)
+		}
+
+		state, err = provider.RefreshWithStrategy(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--e"}, &terraform.InstanceState{
+			ID:         "e",
+			Attributes: map[string]string{"id": "e", "name": "e"},
+			Meta:       map[string]interface{}{"schema_version": "0"},
+		}, RefreshImportUpgradeRead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Attributes["name"] != "e" || state.Attributes["settings.enabled"] != "true" {
+			t.Errorf("resource was not upgraded then read %v", state.Attributes)
+		}
+
+		state, err = provider.RefreshWithStrategy(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--f"}, &terraform.InstanceState{
+			ID:         "f",
+			Attributes: map[string]string{"id": "f", "name": "f"},
+			Meta:       map[string]interface{}{"schema_version": "1"},
+		}, RefreshImportUpgradeRead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Attributes["name"] != "https://example.com/f" || state.Attributes["settings.enabled"] != "true" {
+			t.Errorf("resource failing to upgrade was not imported then read %v", state.Attributes)
+		}
+	})
+}
+
+func TestProtoV6ToConfigSchemaInvalidType(t *testing.T) {
+	_, err := protoV6ToConfigSchema(&proto.Schema_Block{
+		BlockTypes: []*proto.Schema_NestedBlock{{
+			TypeName: "settings",
+			Block: &proto.Schema_Block{
+				Attributes: []*proto.Schema_Attribute{{Name: "enabled", Type: []byte(`"boolean"`)}},
+			},
+		}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "block settings: type of attribute enabled") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
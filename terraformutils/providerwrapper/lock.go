@@ -0,0 +1,284 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProviderLock describes the provider used for import as recorded in .terraform.lock.hcl
+type ProviderLock struct {
+	// Address is the fully qualified source, hostname/namespace/type
+	Address string
+	// Source is the address as written in required_providers, without the default registry hostname
+	Source  string
+	Version string
+	// Hashes holds the h1: hash of the provider package and the zh: hashes of its archives when known
+	Hashes []string
+}
+
+var (
+	hashesMutex sync.Mutex
+	hashes      = map[string][]string{}
+)
+
+// GetProviderLock resolves providerName the same way as import and hashes its package
+func GetProviderLock(providerName string) (*ProviderLock, error) {
+	binary, err := resolveProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	if binary.Version == nil {
+		return nil, fmt.Errorf("can't find version of %s", binary)
+	}
+	address := providerAddress(providerName, binary)
+	lock := &ProviderLock{
+		Address: address,
+		Source:  strings.TrimPrefix(address, DefaultRegistryHost+"/"),
+		Version: binary.Version.String(),
+	}
+	// a legacy plugin dir is shared by many providers, its hash would never match terraform's
+	if binary.Namespace != "" {
+		lock.Hashes, err = packageHashes(binary)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lock, nil
+}
+
+// providerAddress is the fully qualified source of binary
+func providerAddress(providerName string, binary providerBinary) string {
+	if binary.Namespace != "" {
+		return binary.Hostname + "/" + binary.Namespace + "/" + binary.Name
+	}
+	// legacy plugin dirs don't record the source, use the required one
+	source := getProviderRequirement(providerName).Source
+	if source == "" {
+		source = "hashicorp/" + providerName
+	}
+	return normalizeSource(source)
+}
+
+// LinkProviderMirror links the package of the provider used for import into dir, laid out as an
+// unpacked filesystem mirror, so `terraform init -plugin-dir=dir` installs the same binary and
+// the hashes of the generated lock file match
+func LinkProviderMirror(providerName, dir string) error {
+	binary, err := resolveProvider(providerName)
+	if err != nil {
+		return err
+	}
+	if binary.Version == nil {
+		return fmt.Errorf("can't find version of %s", binary)
+	}
+	target := filepath.Join(dir, filepath.FromSlash(providerAddress(providerName, binary)), binary.Version.String(), pluginMachineName)
+	if err := os.MkdirAll(target, os.ModePerm); err != nil {
+		return err
+	}
+	files := []string{binary.Path}
+	// a legacy plugin dir is shared by many providers, only its binary belongs to the package
+	if binary.Namespace != "" {
+		entries, err := os.ReadDir(filepath.Dir(binary.Path))
+		if err != nil {
+			return err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(filepath.Dir(binary.Path), entry.Name()))
+			}
+		}
+	}
+	for _, file := range files {
+		absolute, err := filepath.Abs(file)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(absolute, filepath.Join(target, filepath.Base(file))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func packageHashes(binary providerBinary) ([]string, error) {
+	key := binary.Path
+	if binary.Archive != "" {
+		key = binary.Archive
+	}
+	hashesMutex.Lock()
+	defer hashesMutex.Unlock()
+	if cached, exist := hashes[key]; exist {
+		return cached, nil
+	}
+	var result []string
+	if binary.Archive != "" {
+		h1, err := hashZip(binary.Archive)
+		if err != nil {
+			return nil, err
+		}
+		zh, err := archiveHashes(binary.Archive)
+		if err != nil {
+			return nil, err
+		}
+		result = append([]string{h1}, zh...)
+	} else {
+		h1, err := hashDir(filepath.Dir(binary.Path))
+		if err != nil {
+			return nil, err
+		}
+		result = []string{h1}
+	}
+	hashes[key] = result
+	return result, nil
+}
+
+// archiveHashes returns the sorted "zh:" hashes of archive and of the archives of the same
+// version for other platforms, found next to it or in the SHA256SUMS file of the release, so
+// the lock file also verifies on machines of other platforms using the same mirror
+func archiveHashes(archive string) ([]string, error) {
+	dir := filepath.Dir(archive)
+	// terraform-provider-TYPE_VERSION_ prefixes the archives of every platform
+	prefix := strings.TrimSuffix(filepath.Base(archive), pluginMachineName+".zip")
+	zh := map[string]bool{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isPlatformArchive(entry.Name(), prefix) {
+			continue
+		}
+		sum, err := hashFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		zh[hex.EncodeToString(sum)] = true
+	}
+	sums, err := os.ReadFile(filepath.Join(dir, prefix+"SHA256SUMS"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(sums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && isPlatformArchive(fields[1], prefix) {
+			zh[strings.ToLower(fields[0])] = true
+		}
+	}
+	result := make([]string, 0, len(zh))
+	for sum := range zh {
+		result = append(result, "zh:"+sum)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// isPlatformArchive reports whether name is prefix followed by an OS_ARCH target and .zip
+func isPlatformArchive(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".zip") {
+		return false
+	}
+	return strings.Count(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".zip"), "_") == 1
+}
+
+// hash1 is the "h1:" directory hash used by go modules and terraform: sha256 of the sorted
+// "<sha256 hex>  <file name>" lines of every file in the package
+func hash1(files []string, open func(string) (io.ReadCloser, error)) (string, error) {
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	summary := sha256.New()
+	for _, file := range files {
+		if strings.Contains(file, "\n") {
+			return "", errors.New("file names with new lines are not supported")
+		}
+		reader, err := open(file)
+		if err != nil {
+			return "", err
+		}
+		fileHash := sha256.New()
+		_, err = io.Copy(fileHash, reader)
+		reader.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(summary, "%x  %s\n", fileHash.Sum(nil), file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+func hashDir(dir string) (string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hash1(files, func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	})
+}
+
+func hashZip(path string) (string, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	files := []string{}
+	entries := map[string]*zip.File{}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, file.Name)
+		entries[file.Name] = file
+	}
+	return hash1(files, func(name string) (io.ReadCloser, error) {
+		return entries[name].Open()
+	})
+}
+
+func hashFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
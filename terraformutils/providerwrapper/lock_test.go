@@ -0,0 +1,157 @@
+package providerwrapper //nolint
+
+import (
+	"archive/zip"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var lockTestFiles = map[string]string{
+	"terraform-provider-foo_v1.0.0": "binary",
+	"LICENSE":                       "license\n",
+}
+
+// h1 computed by golang.org/x/mod/sumdb/dirhash.HashDir for lockTestFiles
+const lockTestHash = "h1:zd4pqaqp4QVjvfo/bZcaN0ihERmDhK8z99efXSNwAJo="
+
+func TestGetProviderLock(t *testing.T) {
+	root := newProviderDirs(t)
+	dir := filepath.Join(root, ".terraform", "providers", "example.com", "corp", "foo", "1.0.0", pluginMachineName)
+	for name, content := range lockTestFiles {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	SetProviderRequirement("foo", ProviderRequirement{Source: "example.com/corp/foo"})
+	lock, err := GetProviderLock("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ProviderLock{
+		Address: "example.com/corp/foo",
+		Source:  "example.com/corp/foo",
+		Version: "1.0.0",
+		Hashes:  []string{lockTestHash},
+	}
+	if !reflect.DeepEqual(lock, expected) {
+		t.Errorf("expected %+v, got %+v", expected, lock)
+	}
+
+	SetProviderRequirement("foo", ProviderRequirement{Source: "hashicorp/foo"})
+	lock, err = GetProviderLock("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Address != "registry.terraform.io/hashicorp/foo" || lock.Source != "hashicorp/foo" || lock.Version != "2.0.0" {
+		t.Errorf("unexpected lock %+v", lock)
+	}
+}
+
+// writeLockTestArchive packs lockTestFiles into the zip archive at path
+func writeLockTestArchive(t *testing.T, path string) {
+	archive, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	w := zip.NewWriter(archive)
+	for name, content := range lockTestFiles {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetProviderLockFromArchive(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "terraform-provider-foo_1.0.0_"+pluginMachineName+".zip")
+	writeLockTestArchive(t, path)
+
+	got, err := packageHashes(providerBinary{Archive: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != lockTestHash || !strings.HasPrefix(got[1], "zh:") {
+		t.Errorf("unexpected hashes %v", got)
+	}
+}
+
+func TestArchiveHashesOtherPlatforms(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "terraform-provider-foo_1.0.0_"+pluginMachineName+".zip")
+	writeLockTestArchive(t, path)
+	files := map[string]string{
+		"terraform-provider-foo_1.0.0_plan9_arm.zip": "other platform",
+		// other versions and files aren't hashed
+		"terraform-provider-foo_1.1.0_plan9_arm.zip": "other version",
+		"terraform-provider-foo_1.0.0_SHA256SUMS": strings.Join([]string{
+			"AB01  terraform-provider-foo_1.0.0_windows_amd64.zip",
+			"cd02  terraform-provider-foo_1.0.0_manifest.json",
+		}, "\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	local, err := hashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := hashFile(filepath.Join(root, "terraform-provider-foo_1.0.0_plan9_arm.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := archiveHashes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"zh:ab01", "zh:" + hex.EncodeToString(local), "zh:" + hex.EncodeToString(other)}
+	sort.Strings(expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestLinkProviderMirror(t *testing.T) {
+	root := newProviderDirs(t)
+	dir := filepath.Join(root, ".terraform", "providers", "example.com", "corp", "foo", "1.0.0", pluginMachineName)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range lockTestFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	SetProviderRequirement("foo", ProviderRequirement{Source: "example.com/corp/foo"})
+	mirror := t.TempDir()
+	if err := LinkProviderMirror("foo", mirror); err != nil {
+		t.Fatal(err)
+	}
+	h1, err := hashDir(filepath.Join(mirror, "example.com", "corp", "foo", "1.0.0", pluginMachineName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != lockTestHash {
+		t.Errorf("mirror hash %s doesn't match the package hash %s", h1, lockTestHash)
+	}
+}
@@ -15,18 +15,21 @@
 package providerwrapper //nolint
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"runtime"
-	"strings"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/terraformerstring"
 
 	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
@@ -49,7 +52,7 @@ const DefaultPluginVendorDirV12 = "terraform.d/plugins/" + pluginMachineName
 const pluginMachineName = runtime.GOOS + "_" + runtime.GOARCH
 
 type ProviderWrapper struct {
-	Provider     *tfplugin.GRPCProvider
+	Provider     providers.Interface
 	client       *plugin.Client
 	rpcClient    plugin.ClientProtocol
 	providerName string
@@ -57,13 +60,42 @@ type ProviderWrapper struct {
 	schema       *providers.GetSchemaResponse
 	retryCount   int
 	retrySleepMs int
+	ctx          context.Context
+	stopKill     func() bool
+	verbose      bool
+	// pluginMutex guards the plugin fields replaced by restart
+	pluginMutex sync.RWMutex
+	// isolation lets resources suspected of crashing the plugin be read alone
+	isolation sync.RWMutex
+	restarts  int
+	crashed   []string
 }
 
 func NewProviderWrapper(providerName string, providerConfig cty.Value, verbose bool, options ...map[string]int) (*ProviderWrapper, error) {
-	p := &ProviderWrapper{retryCount: 5, retrySleepMs: 300}
+	p := &ProviderWrapper{retryCount: 5, retrySleepMs: 300, ctx: context.Background()}
 	p.providerName = providerName
 	p.config = providerConfig
+	p.verbose = verbose
+	p.setOptions(options)
 
+	err := p.initProvider(verbose)
+
+	return p, err
+}
+
+// NewProviderWrapperWithProvider wraps a provider served in process, such as a test fake, instead
+// of starting the plugin of providerName. The provider is used as is, it isn't configured.
+func NewProviderWrapperWithProvider(providerName string, provider providers.Interface, verbose bool, options ...map[string]int) *ProviderWrapper {
+	p := &ProviderWrapper{retryCount: 5, retrySleepMs: 300, ctx: context.Background()}
+	p.providerName = providerName
+	p.Provider = provider
+	p.verbose = verbose
+	p.setOptions(options)
+	return p
+}
+
+// setOptions reads the retryCount and retrySleepMs options
+func (p *ProviderWrapper) setOptions(options []map[string]int) {
 	if len(options) > 0 {
 		retryCount, hasOption := options[0]["retryCount"]
 		if hasOption {
@@ -74,16 +106,34 @@ func NewProviderWrapper(providerName string, providerConfig cty.Value, verbose b
 			p.retrySleepMs = retrySleepMs
 		}
 	}
-
-	err := p.initProvider(verbose)
-
-	return p, err
 }
 
 func (p *ProviderWrapper) Kill() {
+	if p.stopKill != nil {
+		p.stopKill()
+	}
+	p.kill()
+}
+
+func (p *ProviderWrapper) kill() {
+	p.pluginMutex.RLock()
+	defer p.pluginMutex.RUnlock()
+	if p.client == nil {
+		_ = p.Provider.Close()
+		return
+	}
 	p.client.Kill()
 }
 
+// SetContext stops retries once ctx is done and kills the plugin so in-flight calls return
+func (p *ProviderWrapper) SetContext(ctx context.Context) {
+	if p.stopKill != nil {
+		p.stopKill()
+	}
+	p.ctx = ctx
+	p.stopKill = context.AfterFunc(ctx, p.kill)
+}
+
 func (p *ProviderWrapper) GetSchema() *providers.GetSchemaResponse {
 	if p.schema == nil {
 		r := p.Provider.GetSchema()
@@ -157,61 +207,203 @@ func (p *ProviderWrapper) readObjBlocks(block map[string]*configschema.NestedBlo
 	return readOnlyAttributes
 }
 
+// RefreshStrategy is the sequence of provider calls used to refresh a resource
+type RefreshStrategy int
+
+const (
+	// RefreshRead reads the resource from its attributes and imports it by ID when reads keep failing
+	RefreshRead RefreshStrategy = iota
+	// RefreshImportUpgradeRead imports the resource by ID when it has no attributes or they
+	// can't be upgraded, otherwise upgrades its attributes from their recorded schema version,
+	// then reads it
+	RefreshImportUpgradeRead
+)
+
 func (p *ProviderWrapper) Refresh(info *terraform.InstanceInfo, state *terraform.InstanceState) (*terraform.InstanceState, error) {
+	return p.RefreshWithStrategy(info, state, RefreshRead)
+}
+
+func (p *ProviderWrapper) RefreshWithStrategy(info *terraform.InstanceInfo, state *terraform.InstanceState, strategy RefreshStrategy) (*terraform.InstanceState, error) {
+	_, newState, err := p.RefreshValueWithStrategy(info, state, strategy)
+	return newState, err
+}
+
+// RefreshValueWithStrategy returns the refreshed value as read from the provider along with
+// its legacy flatmap state
+func (p *ProviderWrapper) RefreshValueWithStrategy(info *terraform.InstanceInfo, state *terraform.InstanceState, strategy RefreshStrategy) (cty.Value, *terraform.InstanceState, error) {
+	value, err := p.refreshValue(info, state, strategy)
+	if err != nil {
+		return cty.NilVal, nil, err
+	}
+	version := p.GetSchema().ResourceTypes[info.Type].Version
+	return value, terraform.NewInstanceStateShimmedFromValue(value, int(version)), nil
+}
+
+// refresh reads the resource with provider, a plugin started by initProvider
+func (p *ProviderWrapper) refresh(provider providers.Interface, info *terraform.InstanceInfo, state *terraform.InstanceState, strategy RefreshStrategy) (cty.Value, error) {
 	schema := p.GetSchema()
-	impliedType := schema.ResourceTypes[info.Type].Block.ImpliedType()
-	priorState, err := state.AttrsAsObjectValue(impliedType)
+	resourceSchema, exist := schema.ResourceTypes[info.Type]
+	if !exist {
+		return cty.NilVal, fmt.Errorf("resource type %s is not supported by provider %s", info.Type, p.providerName)
+	}
+	impliedType := resourceSchema.Block.ImpliedType()
+
+	var priorState cty.Value
+	var private []byte
+	var err error
+	imported := false
+	switch {
+	case strategy == RefreshImportUpgradeRead && !hasAttributes(state):
+		priorState, private, err = p.importResource(provider, info, state.ID)
+		imported = true
+	case strategy == RefreshImportUpgradeRead:
+		priorState, err = p.upgradeResource(provider, info, state, impliedType, resourceSchema.Version)
+		if err != nil {
+			log.Println(err)
+			log.Println("Fail upgrade resource state, trying import command")
+			priorState, private, err = p.importResource(provider, info, state.ID)
+			imported = true
+		}
+	default:
+		priorState, err = state.AttrsAsObjectValue(impliedType)
+	}
 	if err != nil {
-		return nil, err
+		return cty.NilVal, err
+	}
+
+	resp, successReadResource := p.readResource(provider, info, priorState, private)
+	if err := p.ctx.Err(); err != nil {
+		return cty.NilVal, err
+	}
+	if !successReadResource {
+		if imported {
+			return cty.NilVal, resp.Diagnostics.Err()
+		}
+		log.Println("Fail read resource from provider, trying import command")
+		// retry with regular import command - without resource attributes
+		importedState, _, err := p.importResource(provider, info, state.ID)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		return importedState, nil
+	}
+
+	if resp.NewState.IsNull() {
+		msg := fmt.Sprintf("ERROR: Read resource response is null for resource %s", info.Id)
+		return cty.NilVal, errors.New(msg)
+	}
+
+	return resp.NewState, nil
+}
+
+// readResource calls ReadResource up to retryCount times
+func (p *ProviderWrapper) readResource(provider providers.Interface, info *terraform.InstanceInfo, priorState cty.Value, private []byte) (providers.ReadResourceResponse, bool) {
+	if private == nil {
+		private = []byte{}
 	}
-	successReadResource := false
 	resp := providers.ReadResourceResponse{}
 	for i := 0; i < p.retryCount; i++ {
-		resp = p.Provider.ReadResource(providers.ReadResourceRequest{
+		resp = provider.ReadResource(providers.ReadResourceRequest{
 			TypeName:   info.Type,
 			PriorState: priorState,
-			Private:    []byte{},
+			Private:    private,
 		})
-		if resp.Diagnostics.HasErrors() {
-			log.Println(resp.Diagnostics.Err())
-			log.Printf("WARN: Fail read resource from provider, wait %dms before retry\n", p.retrySleepMs)
-			time.Sleep(time.Duration(p.retrySleepMs) * time.Millisecond)
-			continue
-		} else {
-			successReadResource = true
-			break
+		if !resp.Diagnostics.HasErrors() {
+			return resp, true
+		}
+		log.Println(resp.Diagnostics.Err())
+		if p.exited(provider) {
+			return resp, false
+		}
+		log.Printf("WARN: Fail read resource from provider, wait %dms before retry\n", p.retrySleepMs)
+		select {
+		case <-p.ctx.Done():
+			return resp, false
+		case <-time.After(time.Duration(p.retrySleepMs) * time.Millisecond):
 		}
 	}
+	return resp, false
+}
 
-	if !successReadResource {
-		log.Println("Fail read resource from provider, trying import command")
-		// retry with regular import command - without resource attributes
-		importResponse := p.Provider.ImportResourceState(providers.ImportResourceStateRequest{
-			TypeName: info.Type,
-			ID:       state.ID,
-		})
-		if importResponse.Diagnostics.HasErrors() {
-			return nil, resp.Diagnostics.Err()
-		}
-		if len(importResponse.ImportedResources) == 0 {
-			return nil, errors.New("not able to import resource for a given ID")
+// importResource returns the state and private data the provider imports for id
+func (p *ProviderWrapper) importResource(provider providers.Interface, info *terraform.InstanceInfo, id string) (cty.Value, []byte, error) {
+	importResponse := provider.ImportResourceState(providers.ImportResourceStateRequest{
+		TypeName: info.Type,
+		ID:       id,
+	})
+	if importResponse.Diagnostics.HasErrors() {
+		return cty.NilVal, nil, importResponse.Diagnostics.Err()
+	}
+	if len(importResponse.ImportedResources) == 0 {
+		return cty.NilVal, nil, errors.New("not able to import resource for a given ID")
+	}
+	// some importers return dependent resources too, prefer the requested one
+	for _, imported := range importResponse.ImportedResources {
+		if imported.TypeName == info.Type {
+			return imported.State, imported.Private, nil
 		}
-		return terraform.NewInstanceStateShimmedFromValue(importResponse.ImportedResources[0].State, int(schema.ResourceTypes[info.Type].Version)), nil
 	}
+	return importResponse.ImportedResources[0].State, importResponse.ImportedResources[0].Private, nil
+}
 
-	if resp.NewState.IsNull() {
-		msg := fmt.Sprintf("ERROR: Read resource response is null for resource %s", info.Id)
-		return nil, errors.New(msg)
+// upgradeResource lets the provider migrate state attributes from the schema version they were recorded with
+func (p *ProviderWrapper) upgradeResource(provider providers.Interface, info *terraform.InstanceInfo, state *terraform.InstanceState, impliedType cty.Type, currentVersion int64) (cty.Value, error) {
+	version := currentVersion
+	if recorded, exist := state.Meta["schema_version"]; exist {
+		switch v := recorded.(type) {
+		case int:
+			version = int64(v)
+		case int64:
+			version = v
+		case float64:
+			version = int64(v)
+		case string:
+			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+				version = parsed
+			}
+		}
+	}
+	request := providers.UpgradeResourceStateRequest{
+		TypeName: info.Type,
+		Version:  version,
 	}
+	if _, isV6 := provider.(*GRPCProviderV6); isV6 {
+		// protocol 6 providers don't accept flatmap states
+		value, err := state.AttrsAsObjectValue(impliedType)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		request.RawStateJSON, err = ctyjson.Marshal(value, impliedType)
+		if err != nil {
+			return cty.NilVal, err
+		}
+	} else {
+		request.RawStateFlatmap = state.Attributes
+	}
+	resp := provider.UpgradeResourceState(request)
+	if resp.Diagnostics.HasErrors() {
+		return cty.NilVal, resp.Diagnostics.Err()
+	}
+	return resp.UpgradedState, nil
+}
 
-	return terraform.NewInstanceStateShimmedFromValue(resp.NewState, int(schema.ResourceTypes[info.Type].Version)), nil
+// hasAttributes reports whether state holds more than its ID
+func hasAttributes(state *terraform.InstanceState) bool {
+	for key := range state.Attributes {
+		if key != "id" {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *ProviderWrapper) initProvider(verbose bool) error {
-	providerFilePath, err := getProviderFileName(p.providerName)
+	binary, err := resolveProvider(p.providerName)
 	if err != nil {
 		return err
 	}
+	log.Println("Using provider " + binary.String())
+	providerFilePath := binary.Path
 	options := hclog.LoggerOptions{
 		Name:   "plugin",
 		Level:  hclog.Error,
@@ -225,7 +417,7 @@ func (p *ProviderWrapper) initProvider(verbose bool) error {
 		&plugin.ClientConfig{
 			Cmd:              exec.Command(providerFilePath),
 			HandshakeConfig:  tfplugin.Handshake,
-			VersionedPlugins: tfplugin.VersionedPlugins,
+			VersionedPlugins: versionedPlugins(),
 			Managed:          true,
 			Logger:           logger,
 			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
@@ -240,7 +432,7 @@ func (p *ProviderWrapper) initProvider(verbose bool) error {
 		return err
 	}
 
-	p.Provider = raw.(*tfplugin.GRPCProvider)
+	p.Provider = raw.(providers.Interface)
 
 	config, err := p.GetSchema().Provider.Block.CoerceValue(p.config)
 	if err != nil {
@@ -254,104 +446,28 @@ func (p *ProviderWrapper) initProvider(verbose bool) error {
 	return nil
 }
 
-func getProviderFileName(providerName string) (string, error) {
-	defaultDataDir := os.Getenv("TF_DATA_DIR")
-	if defaultDataDir == "" {
-		defaultDataDir = DefaultDataDir
+// versionedPlugins lets the provider pick plugin protocol 5 or 6
+func versionedPlugins() map[int]plugin.PluginSet {
+	plugins := map[int]plugin.PluginSet{}
+	for version, set := range tfplugin.VersionedPlugins {
+		plugins[version] = set
 	}
-	providerFilePath, err := getProviderFileNameV13andV14(defaultDataDir, providerName)
-	if err != nil || providerFilePath == "" {
-		providerFilePath, err = getProviderFileNameV13andV14(os.Getenv("HOME")+string(os.PathSeparator)+
-			".terraform.d", providerName)
-	}
-	if err != nil || providerFilePath == "" {
-		return getProviderFileNameV12(providerName)
-	}
-	return providerFilePath, nil
-}
-
-func getProviderFileNameV13andV14(prefix, providerName string) (string, error) {
-	// Read terraform v14 file path
-	registryDir := prefix + string(os.PathSeparator) + "providers" + string(os.PathSeparator) +
-		"registry.terraform.io"
-	providerDirs, err := os.ReadDir(registryDir)
-	if err != nil {
-		// Read terraform v13 file path
-		registryDir = prefix + string(os.PathSeparator) + "plugins" + string(os.PathSeparator) +
-			"registry.terraform.io"
-		providerDirs, err = os.ReadDir(registryDir)
-		if err != nil {
-			return "", err
-		}
-	}
-	providerFilePath := ""
-	for _, providerDir := range providerDirs {
-		pluginPath := registryDir + string(os.PathSeparator) + providerDir.Name() +
-			string(os.PathSeparator) + providerName
-		dirs, err := os.ReadDir(pluginPath)
-		if err != nil {
-			continue
-		}
-		for _, dir := range dirs {
-			if !dir.IsDir() {
-				continue
-			}
-			for _, dir := range dirs {
-				fullPluginPath := pluginPath + string(os.PathSeparator) + dir.Name() +
-					string(os.PathSeparator) + runtime.GOOS + "_" + runtime.GOARCH
-				files, err := os.ReadDir(fullPluginPath)
-				if err == nil {
-					for _, file := range files {
-						if strings.HasPrefix(file.Name(), "terraform-provider-"+providerName) {
-							providerFilePath = fullPluginPath + string(os.PathSeparator) + file.Name()
-						}
-					}
-				}
-			}
-		}
-	}
-	return providerFilePath, nil
-}
-
-func getProviderFileNameV12(providerName string) (string, error) {
-	defaultDataDir := os.Getenv("TF_DATA_DIR")
-	if defaultDataDir == "" {
-		defaultDataDir = DefaultDataDir
-	}
-	pluginPath := defaultDataDir + string(os.PathSeparator) + "plugins" + string(os.PathSeparator) + runtime.GOOS + "_" + runtime.GOARCH
-	files, err := os.ReadDir(pluginPath)
-	if err != nil {
-		pluginPath = os.Getenv("HOME") + string(os.PathSeparator) + "." + DefaultPluginVendorDirV12
-		files, err = os.ReadDir(pluginPath)
-		if err != nil {
-			return "", err
-		}
-	}
-	providerFilePath := ""
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-		if strings.HasPrefix(file.Name(), "terraform-provider-"+providerName) {
-			providerFilePath = pluginPath + string(os.PathSeparator) + file.Name()
-		}
+	plugins[6] = plugin.PluginSet{
+		tfplugin.ProviderPluginName: &GRPCProviderV6Plugin{},
 	}
-	return providerFilePath, nil
+	return plugins
 }
 
+// GetProviderVersion returns the exact version of the provider binary used for import
 func GetProviderVersion(providerName string) string {
-	providerFilePath, err := getProviderFileName(providerName)
+	binary, err := resolveProvider(providerName)
 	if err != nil {
-		log.Println("Can't find provider file path. Ensure that you are following https://www.terraform.io/docs/configuration/providers.html#third-party-plugins.")
+		log.Println(err)
 		return ""
 	}
-	t := strings.Split(providerFilePath, string(os.PathSeparator))
-	providerFileName := t[len(t)-1]
-	providerFileNameParts := strings.Split(providerFileName, "_")
-	if len(providerFileNameParts) < 2 {
+	if binary.Version == nil {
 		log.Println("Can't find provider version. Ensure that you are following https://www.terraform.io/docs/configuration/providers.html#plugin-names-and-versions.")
 		return ""
 	}
-	providerVersion := providerFileNameParts[1]
-	return "~> " + strings.TrimPrefix(providerVersion, "v")
+	return binary.Version.String()
 }
@@ -0,0 +1,474 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+)
+
+// DefaultRegistryHost is the hostname of providers whose source has no hostname
+const DefaultRegistryHost = "registry.terraform.io"
+
+// ProviderRequirement narrows down which installed provider binary is used
+type ProviderRequirement struct {
+	// Source is the provider source address, [hostname/]namespace/type
+	Source string
+	// Version is a version constraint like "~> 4.0" or ">= 1.2, < 2.0"
+	Version string
+}
+
+var (
+	requirementsMutex sync.Mutex
+	requirements      = map[string]ProviderRequirement{}
+)
+
+// SetProviderRequirement sets the source and version constraint used to resolve providerName binary
+func SetProviderRequirement(providerName string, requirement ProviderRequirement) {
+	requirementsMutex.Lock()
+	defer requirementsMutex.Unlock()
+	requirements[providerName] = requirement
+}
+
+func getProviderRequirement(providerName string) ProviderRequirement {
+	requirementsMutex.Lock()
+	defer requirementsMutex.Unlock()
+	return requirements[providerName]
+}
+
+// providerBinary is a provider found on disk, Hostname and Namespace are empty for legacy plugin dirs
+type providerBinary struct {
+	Hostname  string
+	Namespace string
+	Name      string
+	Version   *version.Version
+	Path      string
+	// Archive is set for packed filesystem mirrors, Path is known after extraction
+	Archive string
+}
+
+func (b providerBinary) String() string {
+	address := b.Name
+	if b.Namespace != "" {
+		address = b.Hostname + "/" + b.Namespace + "/" + b.Name
+	}
+	location := b.Path
+	if b.Archive != "" {
+		location = b.Archive
+	}
+	if b.Version == nil {
+		return address + " (" + location + ")"
+	}
+	return address + " " + b.Version.String() + " (" + location + ")"
+}
+
+// cliConfig holds the parts of .terraformrc used to find providers
+type cliConfig struct {
+	PluginCacheDir    string `hcl:"plugin_cache_dir"`
+	FilesystemMirrors []filesystemMirror
+}
+
+type filesystemMirror struct {
+	Path    string   `hcl:"path"`
+	Include []string `hcl:"include"`
+	Exclude []string `hcl:"exclude"`
+}
+
+func cliConfigFile() string {
+	if configFile := os.Getenv("TF_CLI_CONFIG_FILE"); configFile != "" {
+		return configFile
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "terraform.rc")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".terraformrc")
+}
+
+func loadCLIConfig() cliConfig {
+	config := cliConfig{}
+	configFile := cliConfigFile()
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return config
+	}
+	if err := parseCLIConfig(content, &config); err != nil {
+		log.Printf("WARN: can't parse terraform CLI config %s: %s\n", configFile, err)
+	}
+	return config
+}
+
+// parseCLIConfig decodes provider_installation blocks by hand, hcl can't decode blocks nested in blocks
+func parseCLIConfig(content []byte, config *cliConfig) error {
+	file, err := hcl.ParseBytes(content)
+	if err != nil {
+		return err
+	}
+	if err := hcl.DecodeObject(config, file.Node); err != nil {
+		return err
+	}
+	root, ok := file.Node.(*ast.ObjectList)
+	if !ok {
+		return nil
+	}
+	for _, installation := range root.Filter("provider_installation").Items {
+		methods, ok := installation.Val.(*ast.ObjectType)
+		if !ok {
+			continue
+		}
+		for _, item := range methods.List.Filter("filesystem_mirror").Items {
+			mirror := filesystemMirror{}
+			if err := hcl.DecodeObject(&mirror, item.Val); err != nil {
+				return err
+			}
+			config.FilesystemMirrors = append(config.FilesystemMirrors, mirror)
+		}
+	}
+	return nil
+}
+
+// providerSearchDirs returns the filesystem mirrors searched for providers, in terraform order
+func providerSearchDirs(config cliConfig) []filesystemMirror {
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = DefaultDataDir
+	}
+	home := os.Getenv("HOME")
+	dirs := []filesystemMirror{
+		{Path: filepath.Join(dataDir, "providers")},
+		{Path: filepath.Join(dataDir, "plugins")},
+	}
+	dirs = append(dirs, config.FilesystemMirrors...)
+	if len(config.FilesystemMirrors) == 0 {
+		// implied local mirrors
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		dirs = append(dirs,
+			filesystemMirror{Path: filepath.Join(home, ".terraform.d", "plugins")},
+			filesystemMirror{Path: filepath.Join(home, ".terraform.d", "providers")},
+			filesystemMirror{Path: filepath.Join(dataHome, "terraform", "plugins")},
+		)
+	}
+	cacheDir := os.Getenv("TF_PLUGIN_CACHE_DIR")
+	if cacheDir == "" {
+		cacheDir = config.PluginCacheDir
+	}
+	if cacheDir != "" {
+		dirs = append(dirs, filesystemMirror{Path: expandHome(cacheDir)})
+	}
+	for i := range dirs {
+		dirs[i].Path = expandHome(dirs[i].Path)
+	}
+	return dirs
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[2:])
+	}
+	return path
+}
+
+// findProviderBinaries lists every terraform-provider-<providerName> binary installed for this platform
+func findProviderBinaries(providerName string) []providerBinary {
+	binaries := []providerBinary{}
+	for _, mirror := range providerSearchDirs(loadCLIConfig()) {
+		for _, binary := range findInMirror(mirror.Path, providerName) {
+			if mirror.allows(binary) {
+				binaries = append(binaries, binary)
+			}
+		}
+	}
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = DefaultDataDir
+	}
+	for _, legacyDir := range []string{
+		filepath.Join(dataDir, "plugins", pluginMachineName),
+		filepath.Join(os.Getenv("HOME"), "."+DefaultPluginVendorDirV12),
+	} {
+		binaries = append(binaries, findLegacy(legacyDir, providerName)...)
+	}
+	return binaries
+}
+
+// findInMirror reads HOSTNAME/NAMESPACE/TYPE/VERSION/TARGET/ (unpacked) and
+// HOSTNAME/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_TARGET.zip (packed) layouts
+func findInMirror(root, providerName string) []providerBinary {
+	binaries := []providerBinary{}
+	hosts, err := os.ReadDir(root)
+	if err != nil {
+		return binaries
+	}
+	for _, host := range hosts {
+		namespaces, err := os.ReadDir(filepath.Join(root, host.Name()))
+		if err != nil {
+			continue
+		}
+		for _, namespace := range namespaces {
+			typeDir := filepath.Join(root, host.Name(), namespace.Name(), providerName)
+			entries, err := os.ReadDir(typeDir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				binary := providerBinary{Hostname: host.Name(), Namespace: namespace.Name(), Name: providerName}
+				if entry.IsDir() {
+					v, err := version.NewVersion(entry.Name())
+					if err != nil {
+						continue
+					}
+					binary.Version = v
+					binary.Path = findExecutable(filepath.Join(typeDir, entry.Name(), pluginMachineName), providerName)
+					if binary.Path != "" {
+						binaries = append(binaries, binary)
+					}
+					continue
+				}
+				prefix := "terraform-provider-" + providerName + "_"
+				suffix := "_" + pluginMachineName + ".zip"
+				if !strings.HasPrefix(entry.Name(), prefix) || !strings.HasSuffix(entry.Name(), suffix) {
+					continue
+				}
+				v, err := version.NewVersion(strings.TrimSuffix(strings.TrimPrefix(entry.Name(), prefix), suffix))
+				if err != nil {
+					continue
+				}
+				binary.Version = v
+				binary.Archive = filepath.Join(typeDir, entry.Name())
+				binaries = append(binaries, binary)
+			}
+		}
+	}
+	return binaries
+}
+
+func findExecutable(dir, providerName string) string {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, file := range files {
+		if !file.IsDir() && isProviderExecutable(file.Name(), providerName) {
+			return filepath.Join(dir, file.Name())
+		}
+	}
+	return ""
+}
+
+// isProviderExecutable matches terraform-provider-<name>, optionally followed by _v<version> or .exe
+func isProviderExecutable(fileName, providerName string) bool {
+	rest := strings.TrimPrefix(fileName, "terraform-provider-"+providerName)
+	if rest == fileName {
+		return false
+	}
+	return rest == "" || rest == ".exe" || strings.HasPrefix(rest, "_")
+}
+
+// findLegacy reads terraform 0.12 plugin dirs holding terraform-provider-<name>_v<version> files
+func findLegacy(dir, providerName string) []providerBinary {
+	binaries := []providerBinary{}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return binaries
+	}
+	for _, file := range files {
+		if file.IsDir() || !isProviderExecutable(file.Name(), providerName) {
+			continue
+		}
+		binary := providerBinary{Name: providerName, Path: filepath.Join(dir, file.Name())}
+		parts := strings.Split(file.Name(), "_")
+		if len(parts) > 1 {
+			if v, err := version.NewVersion(parts[1]); err == nil {
+				binary.Version = v
+			}
+		}
+		binaries = append(binaries, binary)
+	}
+	return binaries
+}
+
+// allows applies include and exclude patterns of a filesystem_mirror block
+func (m filesystemMirror) allows(binary providerBinary) bool {
+	address := binary.Hostname + "/" + binary.Namespace + "/" + binary.Name
+	for _, pattern := range m.Exclude {
+		if matchSourcePattern(pattern, address) {
+			return false
+		}
+	}
+	if len(m.Include) == 0 {
+		return true
+	}
+	for _, pattern := range m.Include {
+		if matchSourcePattern(pattern, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSourcePattern(pattern, address string) bool {
+	patternParts := strings.Split(normalizeSource(pattern), "/")
+	addressParts := strings.Split(address, "/")
+	if len(patternParts) != len(addressParts) {
+		return false
+	}
+	for i := range patternParts {
+		if patternParts[i] != "*" && !strings.EqualFold(patternParts[i], addressParts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeSource adds the default registry hostname to namespace/type sources
+func normalizeSource(source string) string {
+	if strings.Count(source, "/") == 1 {
+		return DefaultRegistryHost + "/" + source
+	}
+	return source
+}
+
+// resolveProvider returns the highest version of providerName matching its requirement
+func resolveProvider(providerName string) (providerBinary, error) {
+	requirement := getProviderRequirement(providerName)
+	var constraints version.Constraints
+	if requirement.Version != "" {
+		var err error
+		constraints, err = version.NewConstraint(requirement.Version)
+		if err != nil {
+			return providerBinary{}, fmt.Errorf("invalid provider version constraint %q: %s", requirement.Version, err)
+		}
+	}
+	// namespace/type sources match any hostname, mirrors and private registries keep working
+	var hostname, namespace string
+	if requirement.Source != "" {
+		parts := strings.Split(requirement.Source, "/")
+		switch len(parts) {
+		case 2:
+			namespace = parts[0]
+		case 3:
+			hostname, namespace = parts[0], parts[1]
+		}
+	}
+
+	found := findProviderBinaries(providerName)
+	if len(found) == 0 {
+		return providerBinary{}, fmt.Errorf("terraform-provider-%s not found, run terraform init with this provider first or see https://www.terraform.io/docs/configuration/providers.html#third-party-plugins", providerName)
+	}
+	candidates := []providerBinary{}
+	for _, binary := range found {
+		// legacy plugin dirs don't record hostname and namespace
+		if namespace != "" && binary.Namespace != "" && !strings.EqualFold(binary.Namespace, namespace) {
+			continue
+		}
+		if hostname != "" && binary.Namespace != "" && !strings.EqualFold(binary.Hostname, hostname) {
+			continue
+		}
+		if constraints != nil && (binary.Version == nil || !constraints.Check(binary.Version)) {
+			continue
+		}
+		candidates = append(candidates, binary)
+	}
+	if len(candidates) == 0 {
+		descriptions := []string{}
+		for _, binary := range found {
+			descriptions = append(descriptions, binary.String())
+		}
+		return providerBinary{}, fmt.Errorf("no terraform-provider-%s matches source %q and version %q, found:\n  %s",
+			providerName, requirement.Source, requirement.Version, strings.Join(descriptions, "\n  "))
+	}
+	// stable sort keeps the search order for equal versions, so .terraform wins over mirrors
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Version == nil || candidates[j].Version == nil {
+			return candidates[i].Version != nil
+		}
+		return candidates[i].Version.GreaterThan(candidates[j].Version)
+	})
+	selected := candidates[0]
+	if selected.Archive != "" {
+		path, err := extractProvider(selected)
+		if err != nil {
+			return providerBinary{}, err
+		}
+		selected.Path = path
+	}
+	return selected, nil
+}
+
+// extractProvider unpacks a packed mirror archive into the user cache dir and returns the binary path
+func extractProvider(binary providerBinary) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, "terraformer", "providers", binary.Hostname, binary.Namespace, binary.Name,
+		binary.Version.String(), pluginMachineName)
+	if path := findExecutable(dir, binary.Name); path != "" {
+		return path, nil
+	}
+	archive, err := zip.OpenReader(binary.Archive)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || strings.Contains(file.Name, "..") {
+			continue
+		}
+		if err := extractFile(file, filepath.Join(dir, filepath.Base(file.Name))); err != nil {
+			return "", err
+		}
+	}
+	path := findExecutable(dir, binary.Name)
+	if path == "" {
+		return "", errors.New("no provider executable in " + binary.Archive)
+	}
+	return path, nil
+}
+
+func extractFile(file *zip.File, target string) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	writer, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, reader) //nolint:gosec
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package providerwrapper //nolint
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func touchProvider(t *testing.T, path string) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte{}, 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newProviderDirs isolates provider lookup in a temp dir and installs foo providers in .terraform
+func newProviderDirs(t *testing.T) string {
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv("TF_DATA_DIR", filepath.Join(root, ".terraform"))
+	t.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(root, "terraformrc"))
+	t.Setenv("TF_PLUGIN_CACHE_DIR", "")
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(root, "cache"))
+	for _, p := range []string{"hashicorp/foo/1.0.0", "hashicorp/foo/2.0.0", "someone/foo/3.0.0"} {
+		touchProvider(t, filepath.Join(root, ".terraform", "providers", "registry.terraform.io", p, pluginMachineName, "terraform-provider-foo_v"+filepath.Base(p)))
+	}
+	return root
+}
+
+func TestResolveProvider(t *testing.T) {
+	newProviderDirs(t)
+
+	testCases := map[string]struct {
+		requirement ProviderRequirement
+		expected    string
+		err         string
+	}{
+		"highest":            {ProviderRequirement{}, "registry.terraform.io/someone/foo 3.0.0", ""},
+		"namespace":          {ProviderRequirement{Source: "hashicorp/foo"}, "registry.terraform.io/hashicorp/foo 2.0.0", ""},
+		"version":            {ProviderRequirement{Source: "hashicorp/foo", Version: "~> 1.0"}, "registry.terraform.io/hashicorp/foo 1.0.0", ""},
+		"hostname":           {ProviderRequirement{Source: "example.com/hashicorp/foo"}, "", "no terraform-provider-foo matches"},
+		"unsatisfiable":      {ProviderRequirement{Version: "> 5"}, "", "registry.terraform.io/someone/foo 3.0.0"},
+		"invalid constraint": {ProviderRequirement{Version: "latest"}, "", "invalid provider version constraint"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			SetProviderRequirement("foo", tc.requirement)
+			binary, err := resolveProvider("foo")
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(binary.String(), tc.expected+" (") {
+				t.Errorf("expected %s, got %s", tc.expected, binary)
+			}
+		})
+	}
+}
+
+func TestResolveProviderFromPackedMirror(t *testing.T) {
+	root := newProviderDirs(t)
+	mirror := filepath.Join(root, "mirror", "example.com", "corp", "foo")
+	if err := os.MkdirAll(mirror, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := os.Create(filepath.Join(mirror, "terraform-provider-foo_4.0.0_"+pluginMachineName+".zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(archive)
+	if _, err := w.Create("terraform-provider-foo_v4.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive.Close()
+	config := `provider_installation {
+  filesystem_mirror {
+    path    = "` + filepath.ToSlash(filepath.Join(root, "mirror")) + `"
+    include = ["example.com/*/*"]
+  }
+}`
+	if err := os.WriteFile(filepath.Join(root, "terraformrc"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	SetProviderRequirement("foo", ProviderRequirement{Source: "example.com/corp/foo"})
+	binary, err := resolveProvider("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.Version.String() != "4.0.0" {
+		t.Errorf("expected mirrored provider, got %s", binary)
+	}
+	if _, err := os.Stat(binary.Path); err != nil {
+		t.Errorf("provider was not extracted: %s", err)
+	}
+}
+
+func TestGetProviderVersionFromLegacyPluginDir(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("TF_DATA_DIR", filepath.Join(root, ".terraform"))
+	t.Setenv("TF_CLI_CONFIG_FILE", filepath.Join(root, "terraformrc"))
+	t.Setenv("TF_PLUGIN_CACHE_DIR", "")
+	touchProvider(t, filepath.Join(root, "."+DefaultPluginVendorDirV12, "terraform-provider-bar_v0.5.0_x4"))
+
+	SetProviderRequirement("bar", ProviderRequirement{Source: "hashicorp/bar"})
+	if version := GetProviderVersion("bar"); version != "0.5.0" {
+		t.Errorf("unexpected version %q", version)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package providerwrapper //nolint
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxRestarts bounds how many times a crashing plugin is restarted during an import
+const maxRestarts = 10
+
+// refreshValue refreshes the resource, restarting the plugin if it crashes meanwhile.
+// Resources in flight during a crash are read again one at a time, a resource that crashes
+// the plugin on its own is recorded in CrashedResources and skipped.
+func (p *ProviderWrapper) refreshValue(info *terraform.InstanceInfo, state *terraform.InstanceState, strategy RefreshStrategy) (cty.Value, error) {
+	if err := p.ctx.Err(); err != nil {
+		return cty.NilVal, err
+	}
+	provider, newState, err := p.refreshShared(info, state, strategy)
+	if err == nil || !p.exited(provider) {
+		return newState, err
+	}
+	if err := p.restart(provider); err != nil {
+		return cty.NilVal, err
+	}
+
+	p.isolation.Lock()
+	defer p.isolation.Unlock()
+	provider = p.plugin()
+	newState, err = p.refresh(provider, info, state, strategy)
+	if err == nil || !p.exited(provider) {
+		return newState, err
+	}
+	p.recordCrash(info)
+	if err := p.restart(provider); err != nil {
+		return cty.NilVal, err
+	}
+	return cty.NilVal, fmt.Errorf("provider %s crashed reading %s %s, skipping it", p.providerName, info.Type, state.ID)
+}
+
+// refreshShared refreshes the resource alongside the other workers
+func (p *ProviderWrapper) refreshShared(info *terraform.InstanceInfo, state *terraform.InstanceState, strategy RefreshStrategy) (providers.Interface, cty.Value, error) {
+	p.isolation.RLock()
+	defer p.isolation.RUnlock()
+	provider := p.plugin()
+	newState, err := p.refresh(provider, info, state, strategy)
+	return provider, newState, err
+}
+
+// plugin returns the running plugin
+func (p *ProviderWrapper) plugin() providers.Interface {
+	p.pluginMutex.RLock()
+	defer p.pluginMutex.RUnlock()
+	return p.Provider
+}
+
+// exited reports whether provider crashed, a plugin killed on cancellation didn't crash
+func (p *ProviderWrapper) exited(provider providers.Interface) bool {
+	if p.ctx.Err() != nil {
+		return false
+	}
+	p.pluginMutex.RLock()
+	defer p.pluginMutex.RUnlock()
+	if provider != p.Provider {
+		return true // another worker restarted it already
+	}
+	if p.client == nil {
+		return false // served in process
+	}
+	return p.client.Exited() || p.rpcClient.Ping() != nil
+}
+
+// restart starts and configures a new plugin if crashed is still the running one
+func (p *ProviderWrapper) restart(crashed providers.Interface) error {
+	p.pluginMutex.Lock()
+	defer p.pluginMutex.Unlock()
+	if crashed != p.Provider {
+		return nil
+	}
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	if p.restarts >= maxRestarts {
+		return fmt.Errorf("provider %s crashed %d times, giving up", p.providerName, p.restarts+1)
+	}
+	p.restarts++
+	log.Printf("WARN: provider %s exited unexpectedly, restarting it\n", p.providerName)
+	p.client.Kill()
+	return p.initProvider(p.verbose)
+}
+
+func (p *ProviderWrapper) recordCrash(info *terraform.InstanceInfo) {
+	p.pluginMutex.Lock()
+	defer p.pluginMutex.Unlock()
+	log.Printf("ERROR: provider %s crashed reading %s, skipping it\n", p.providerName, info.Id)
+	p.crashed = append(p.crashed, info.Type+"."+info.Id)
+}
+
+// CrashedResources lists the resources, as type.name, that crashed the plugin and were skipped
+func (p *ProviderWrapper) CrashedResources() []string {
+	p.pluginMutex.RLock()
+	defer p.pluginMutex.RUnlock()
+	return append([]string(nil), p.crashed...)
+}
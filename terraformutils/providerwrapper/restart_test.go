@@ -0,0 +1,53 @@
+package providerwrapper //nolint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestRefreshRestartsCrashedPlugin(t *testing.T) {
+	provider := newV6TestProvider(t)
+
+	names := []string{"a", "b", "crash", "c", "d", "e", "f", "g"}
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			state, err := provider.Refresh(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--" + name}, &terraform.InstanceState{
+				ID:         name,
+				Attributes: map[string]string{"id": name, "name": name},
+			})
+			if err == nil && state.Attributes["name"] != name {
+				err = fmt.Errorf("unexpected attributes %v", state.Attributes)
+			}
+			errs[i] = err
+		}(i, name)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		switch {
+		case name == "crash" && (errs[i] == nil || !strings.Contains(errs[i].Error(), "crashed")):
+			t.Errorf("expected crash error for %s, got %v", name, errs[i])
+		case name != "crash" && errs[i] != nil:
+			t.Errorf("%s: %v", name, errs[i])
+		}
+	}
+	if crashed := provider.CrashedResources(); len(crashed) != 1 || crashed[0] != "v6test_thing.tfer--crash" {
+		t.Errorf("unexpected crashed resources %v", crashed)
+	}
+
+	state, err := provider.Refresh(&terraform.InstanceInfo{Type: "v6test_thing", Id: "tfer--h"}, &terraform.InstanceState{
+		ID:         "h",
+		Attributes: map[string]string{"id": "h", "name": "h"},
+	})
+	if err != nil || state.Attributes["name"] != "h" {
+		t.Errorf("restarted plugin can't refresh: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// terraform-provider-v6test is a provider speaking only plugin protocol 6, used by providerwrapper tests.
+// It has a single v6test_thing resource with a nested attribute.
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"github.com/zclconf/go-cty/cty/msgpack"
+	"google.golang.org/grpc"
+
+	proto "github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper/tfplugin6"
+)
+
+var providerType = cty.Object(map[string]cty.Type{"endpoint": cty.String})
+
+var thingType = cty.Object(map[string]cty.Type{
+	"id":       cty.String,
+	"name":     cty.String,
+	"settings": cty.Object(map[string]cty.Type{"enabled": cty.Bool}),
+})
+
+type server struct {
+	proto.UnimplementedProviderServer
+	endpoint string
+}
+
+func (s *server) GetProviderSchema(context.Context, *proto.GetProviderSchema_Request) (*proto.GetProviderSchema_Response, error) {
+	return &proto.GetProviderSchema_Response{
+		Provider: &proto.Schema{Block: &proto.Schema_Block{
+			Attributes: []*proto.Schema_Attribute{
+				{Name: "endpoint", Type: []byte(`"string"`), Optional: true},
+			},
+		}},
+		ResourceSchemas: map[string]*proto.Schema{
+			"v6test_thing": {Version: 1, Block: &proto.Schema_Block{
+				Attributes: []*proto.Schema_Attribute{
+					{Name: "id", Type: []byte(`"string"`), Computed: true},
+					{Name: "name", Type: []byte(`"string"`), Required: true},
+					{Name: "settings", Optional: true, NestedType: &proto.Schema_Object{
+						Nesting: proto.Schema_Object_SINGLE,
+						Attributes: []*proto.Schema_Attribute{
+							{Name: "enabled", Type: []byte(`"bool"`), Optional: true},
+						},
+					}},
+				},
+			}},
+		},
+	}, nil
+}
+
+func (s *server) ConfigureProvider(_ context.Context, req *proto.ConfigureProvider_Request) (*proto.ConfigureProvider_Response, error) {
+	config, err := msgpack.Unmarshal(req.Config.Msgpack, providerType)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint := config.GetAttr("endpoint"); !endpoint.IsNull() {
+		s.endpoint = endpoint.AsString()
+	}
+	return &proto.ConfigureProvider_Response{}, nil
+}
+
+// ReadResource enables settings of things, things without name can't be read and
+// reading a thing named "crash" kills the provider
+func (s *server) ReadResource(_ context.Context, req *proto.ReadResource_Request) (*proto.ReadResource_Response, error) {
+	state, err := msgpack.Unmarshal(req.CurrentState.Msgpack, thingType)
+	if err != nil {
+		return nil, err
+	}
+	if name := state.GetAttr("name"); !name.IsNull() && name.AsString() == "crash" {
+		os.Exit(2)
+	}
+	if state.GetAttr("name").IsNull() || state.GetAttr("name").AsString() == "" {
+		return &proto.ReadResource_Response{Diagnostics: []*proto.Diagnostic{{
+			Severity: proto.Diagnostic_ERROR,
+			Summary:  "name is required to read a thing",
+		}}}, nil
+	}
+	newState, err := encode(state.GetAttr("id").AsString(), state.GetAttr("name").AsString())
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ReadResource_Response{NewState: newState, Private: req.Private}, nil
+}
+
+// ImportResourceState names things after the configured endpoint
+func (s *server) ImportResourceState(_ context.Context, req *proto.ImportResourceState_Request) (*proto.ImportResourceState_Response, error) {
+	state, err := encode(req.Id, s.endpoint+"/"+req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ImportResourceState_Response{ImportedResources: []*proto.ImportResourceState_ImportedResource{
+		{TypeName: req.TypeName, State: state},
+	}}, nil
+}
+
+// UpgradeResourceState only upgrades JSON states, states of later schema versions are refused
+func (s *server) UpgradeResourceState(_ context.Context, req *proto.UpgradeResourceState_Request) (*proto.UpgradeResourceState_Response, error) {
+	if req.Version > 0 {
+		return &proto.UpgradeResourceState_Response{Diagnostics: []*proto.Diagnostic{{
+			Severity: proto.Diagnostic_ERROR,
+			Summary:  "unknown schema version",
+		}}}, nil
+	}
+	if req.RawState == nil || len(req.RawState.Json) == 0 {
+		return nil, errors.New("only JSON states are supported")
+	}
+	state, err := ctyjson.Unmarshal(req.RawState.Json, thingType)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := msgpack.Marshal(state, thingType)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.UpgradeResourceState_Response{UpgradedState: &proto.DynamicValue{Msgpack: mp}}, nil
+}
+
+func (s *server) StopProvider(context.Context, *proto.StopProvider_Request) (*proto.StopProvider_Response, error) {
+	return &proto.StopProvider_Response{}, nil
+}
+
+func encode(id, name string) (*proto.DynamicValue, error) {
+	mp, err := msgpack.Marshal(cty.ObjectVal(map[string]cty.Value{
+		"id":       cty.StringVal(id),
+		"name":     cty.StringVal(name),
+		"settings": cty.ObjectVal(map[string]cty.Value{"enabled": cty.True}),
+	}), thingType)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.DynamicValue{Msgpack: mp}, nil
+}
+
+type providerPlugin struct {
+	plugin.Plugin
+}
+
+func (p *providerPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterProviderServer(s, &server{})
+	return nil
+}
+
+func (p *providerPlugin) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, errors.New("server only")
+}
+
+func main() {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: plugin.HandshakeConfig{
+			ProtocolVersion:  6,
+			MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+			MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+		},
+		VersionedPlugins: map[int]plugin.PluginSet{
+			6: {"provider": &providerPlugin{}},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
@@ -0,0 +1,4 @@
+// Package tfplugin6 holds the Terraform plugin protocol version 6 definition and
+// the Go stubs generated from it, copied from terraform-plugin-go v0.25.0 as the
+// protocol definition asks plugin clients to do.
+package tfplugin6
@@ -38,6 +38,16 @@ type Resource struct {
 	AdditionalFields  map[string]interface{} `json:",omitempty"`
 	SlowQueryRequired bool
 	DataFiles         map[string][]byte
+	Variables         map[string]map[string]interface{} `json:",omitempty"`
+	RefreshStrategy   providerwrapper.RefreshStrategy   `json:",omitempty"`
+	// Value is the refreshed value as read from the provider, InstanceState is its flatmap shim
+	Value cty.Value `json:"-"`
+	// IgnorePaths are attribute paths without indexes, e.g. "ingress.self", left out of Item by ConvertValue
+	IgnorePaths []string `json:",omitempty"`
+	// Rules change Item once converted, see ApplyRules
+	Rules *ResourceRules `json:",omitempty"`
+	// DataSources are the data blocks Item links to by type and name, see ConnectDataSources
+	DataSources map[string]map[string]interface{} `json:",omitempty"`
 }
 
 type ApplicableFilter interface {
@@ -122,12 +132,14 @@ func NewSimpleResource(id, resourceName, resourceType, provider string, allowEmp
 	)
 }
 
+// Refresh reads the resource with provider and sets both its refreshed Value and its flatmap
+// InstanceState, the state is the one changed by generators and written out
 func (r *Resource) Refresh(provider *providerwrapper.ProviderWrapper) {
 	var err error
 	if r.SlowQueryRequired {
 		time.Sleep(200 * time.Millisecond)
 	}
-	r.InstanceState, err = provider.Refresh(r.InstanceInfo, r.InstanceState)
+	r.Value, r.InstanceState, err = provider.RefreshValueWithStrategy(r.InstanceInfo, r.InstanceState, r.RefreshStrategy)
 	if err != nil {
 		log.Println(err)
 	}
@@ -154,6 +166,7 @@ func (r *Resource) ParseTFstate(parser Flatmapper, impliedType cty.Type) error {
 	if attributes == nil {
 		attributes = map[string]interface{}{} // ensure HCL can represent empty resource correctly
 	}
+	r.applyItemRules(attributes)
 
 	r.Item = attributes
 	return nil
@@ -176,6 +189,38 @@ func (r *Resource) ConvertTFstate(provider *providerwrapper.ProviderWrapper) err
 	return r.ParseTFstate(parser, impliedType)
 }
 
+// ConvertValue sets Item from Value using the resource schema, resources without value,
+// e.g. loaded from a plan, are converted from their flatmap state
+func (r *Resource) ConvertValue(provider *providerwrapper.ProviderWrapper) error {
+	if r.Value == cty.NilVal || r.Value.IsNull() {
+		return r.ConvertTFstate(provider)
+	}
+	ignoreKeys := []*regexp.Regexp{}
+	for _, pattern := range r.IgnoreKeys {
+		ignoreKeys = append(ignoreKeys, regexp.MustCompile(pattern))
+	}
+	allowEmptyValues := []*regexp.Regexp{}
+	for _, pattern := range r.AllowEmptyValues {
+		if pattern != "" {
+			allowEmptyValues = append(allowEmptyValues, regexp.MustCompile(pattern))
+		}
+	}
+	resourceSchema, exist := provider.GetSchema().ResourceTypes[r.InstanceInfo.Type]
+	if !exist {
+		return fmt.Errorf("resource type %s is not supported by provider %s", r.InstanceInfo.Type, r.Provider)
+	}
+	item := NewValueConverter(ignoreKeys, allowEmptyValues, r.IgnorePaths).Convert(r.Value, resourceSchema.Block)
+	if item == nil {
+		item = map[string]interface{}{} // ensure HCL can represent empty resource correctly
+	}
+	for key, value := range r.AdditionalFields {
+		item[key] = value
+	}
+	r.applyItemRules(item)
+	r.Item = item
+	return nil
+}
+
 func (r *Resource) ServiceName() string {
 	return strings.TrimPrefix(r.InstanceInfo.Type, r.Provider+"_")
 }
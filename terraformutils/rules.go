@@ -0,0 +1,313 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rules override the generated configuration of resource types without changing their
+// generators, they are keyed by resource type and "*" applies to every type. Attribute paths
+// are flatmap keys such as "tags.Name" or "ingress.0.cidr_blocks":
+//
+//	aws_instance:
+//	  ignore: ["^credit_specification($|\\.)"]
+//	  allow_empty: ["^user_data$"]
+//	  set:
+//	    tags.Owner: platform
+//	  replace:
+//	    - attribute: "^tags\\."
+//	      pattern: "^prod-(.*)"
+//	      replacement: "staging-$1"
+//	  lifecycle:
+//	    ignore_changes: [user_data]
+//	    prevent_destroy: true
+type Rules map[string]*ResourceRules
+
+// AllResourceTypes is the Rules key of rules applied to every resource type
+const AllResourceTypes = "*"
+
+// ResourceRules are the rules of a resource type
+type ResourceRules struct {
+	// Ignore are patterns of flatmap keys left out of the configuration, as IgnoreKeys
+	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	// AllowEmpty are patterns of flatmap keys kept when empty, as AllowEmptyValues
+	AllowEmpty []string `yaml:"allow_empty,omitempty" json:"allow_empty,omitempty"`
+	// Set forces the values of attribute paths, nested maps are created as needed
+	Set map[string]interface{} `yaml:"set,omitempty" json:"set,omitempty"`
+	// Replace rewrites the string values of the configuration
+	Replace []ReplaceRule `yaml:"replace,omitempty" json:"replace,omitempty"`
+	// Lifecycle is added to the lifecycle block of every resource of the type
+	Lifecycle *LifecycleRules `yaml:"lifecycle,omitempty" json:"lifecycle,omitempty"`
+}
+
+// ReplaceRule replaces the matches of Pattern with Replacement, where $1 expands to the first
+// group, in the values of the attributes matching the Attribute pattern
+type ReplaceRule struct {
+	Attribute   string `yaml:"attribute" json:"attribute"`
+	Pattern     string `yaml:"pattern" json:"pattern"`
+	Replacement string `yaml:"replacement" json:"replacement"`
+}
+
+// LifecycleRules are the lifecycle meta-arguments set by rules
+type LifecycleRules struct {
+	// IgnoreChanges are top level attributes terraform doesn't plan changes for
+	IgnoreChanges       []string `yaml:"ignore_changes,omitempty" json:"ignore_changes,omitempty"`
+	PreventDestroy      bool     `yaml:"prevent_destroy,omitempty" json:"prevent_destroy,omitempty"`
+	CreateBeforeDestroy bool     `yaml:"create_before_destroy,omitempty" json:"create_before_destroy,omitempty"`
+}
+
+// IgnoreAttributePattern is the Ignore pattern of a top level attribute and its nested keys
+func IgnoreAttributePattern(attribute string) string {
+	return "^" + regexp.QuoteMeta(attribute) + `($|\.)`
+}
+
+// ReadRules reads a rules file and checks its patterns
+func ReadRules(path string) (Rules, error) {
+	rules := Rules{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	for resourceType, resourceRules := range rules {
+		if resourceRules == nil {
+			delete(rules, resourceType)
+			continue
+		}
+		if err := resourceRules.validate(); err != nil {
+			return nil, fmt.Errorf("%s: rules of %s: %w", path, resourceType, err)
+		}
+	}
+	return rules, nil
+}
+
+func (r *ResourceRules) validate() error {
+	patterns := append(append([]string{}, r.Ignore...), r.AllowEmpty...)
+	for _, replace := range r.Replace {
+		patterns = append(patterns, replace.Attribute, replace.Pattern)
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// For merges the rules of every type with the rules of resourceType, nil when there are none
+func (r Rules) For(resourceType string) *ResourceRules {
+	if r[AllResourceTypes] == nil && r[resourceType] == nil {
+		return nil
+	}
+	merged := Rules{}
+	merged.Merge(Rules{resourceType: r[AllResourceTypes]})
+	merged.Merge(Rules{resourceType: r[resourceType]})
+	return merged[resourceType]
+}
+
+// Marshal renders rules as YAML
+func (r Rules) Marshal() ([]byte, error) {
+	return yaml.Marshal(map[string]*ResourceRules(r))
+}
+
+// Merge adds the rules of other, values set by other override the ones of r
+func (r Rules) Merge(other Rules) {
+	for resourceType, otherRules := range other {
+		if otherRules == nil {
+			continue
+		}
+		rules := r[resourceType]
+		if rules == nil {
+			rules = &ResourceRules{}
+			r[resourceType] = rules
+		}
+		rules.Ignore = mergeStrings(rules.Ignore, otherRules.Ignore)
+		rules.AllowEmpty = mergeStrings(rules.AllowEmpty, otherRules.AllowEmpty)
+		for path, value := range otherRules.Set {
+			if rules.Set == nil {
+				rules.Set = map[string]interface{}{}
+			}
+			rules.Set[path] = value
+		}
+		for _, replace := range otherRules.Replace {
+			if !containsReplaceRule(rules.Replace, replace) {
+				rules.Replace = append(rules.Replace, replace)
+			}
+		}
+		if otherRules.Lifecycle != nil {
+			if rules.Lifecycle == nil {
+				rules.Lifecycle = &LifecycleRules{}
+			}
+			rules.Lifecycle.IgnoreChanges = mergeStrings(rules.Lifecycle.IgnoreChanges, otherRules.Lifecycle.IgnoreChanges)
+			rules.Lifecycle.PreventDestroy = rules.Lifecycle.PreventDestroy || otherRules.Lifecycle.PreventDestroy
+			rules.Lifecycle.CreateBeforeDestroy = rules.Lifecycle.CreateBeforeDestroy || otherRules.Lifecycle.CreateBeforeDestroy
+		}
+	}
+}
+
+func containsReplaceRule(rules []ReplaceRule, rule ReplaceRule) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyRules adds the ignore and allow_empty patterns of rules to the resource, the other
+// rules change Item once converted. Call it before ConvertTFstate or ConvertValue.
+func (r *Resource) ApplyRules(rules Rules) {
+	resourceRules := rules.For(r.InstanceInfo.Type)
+	if resourceRules == nil {
+		return
+	}
+	r.IgnoreKeys = append(r.IgnoreKeys, resourceRules.Ignore...)
+	r.AllowEmptyValues = append(r.AllowEmptyValues, resourceRules.AllowEmpty...)
+	r.Rules = resourceRules
+}
+
+// applyItemRules sets, rewrites and adds the lifecycle of the converted item
+func (r *Resource) applyItemRules(item map[string]interface{}) {
+	if r.Rules == nil {
+		return
+	}
+	for _, replace := range r.Rules.Replace {
+		attribute := regexp.MustCompile(replace.Attribute)
+		pattern := regexp.MustCompile(replace.Pattern)
+		for key, value := range item {
+			item[key] = replaceValues(value, key, attribute, pattern, replace.Replacement)
+		}
+	}
+	paths := make([]string, 0, len(r.Rules.Set))
+	for path := range r.Rules.Set {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		item[keys[0]] = setPath(item[keys[0]], keys[1:], r.Rules.Set[path])
+	}
+	if lifecycle := r.Rules.Lifecycle; lifecycle != nil {
+		if len(lifecycle.IgnoreChanges) > 0 {
+			IgnoreChanges(item, lifecycle.IgnoreChanges...)
+		}
+		for name, enabled := range map[string]bool{"prevent_destroy": lifecycle.PreventDestroy, "create_before_destroy": lifecycle.CreateBeforeDestroy} {
+			if enabled {
+				item["lifecycle"] = setPath(item["lifecycle"], []string{name}, true)
+			}
+		}
+	}
+}
+
+// replaceValues returns value with its strings rewritten, key is its flatmap key. Maps and
+// lists are copied as they may be shared, e.g. by AdditionalFields.
+func replaceValues(value interface{}, key string, attribute, pattern *regexp.Regexp, replacement string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if attribute.MatchString(key) {
+			return pattern.ReplaceAllString(v, replacement)
+		}
+	case map[string]interface{}:
+		replaced := make(map[string]interface{}, len(v))
+		for k, element := range v {
+			replaced[k] = replaceValues(element, key+"."+k, attribute, pattern, replacement)
+		}
+		return replaced
+	case []interface{}:
+		replaced := make([]interface{}, len(v))
+		for i, element := range v {
+			replaced[i] = replaceValues(element, key+"."+strconv.Itoa(i), attribute, pattern, replacement)
+		}
+		return replaced
+	}
+	return value
+}
+
+// setPath returns value with newValue set at path, missing maps are created and list elements
+// are indexed. Maps and lists are copied as they may be shared.
+func setPath(value interface{}, path []string, newValue interface{}) interface{} {
+	if len(path) == 0 {
+		return newValue
+	}
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{path[0]: setPath(nil, path[1:], newValue)}
+	case map[string]interface{}:
+		updated := make(map[string]interface{}, len(v)+1)
+		for k, element := range v {
+			updated[k] = element
+		}
+		updated[path[0]] = setPath(v[path[0]], path[1:], newValue)
+		return updated
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(v) {
+			return value
+		}
+		updated := append([]interface{}{}, v...)
+		updated[index] = setPath(v[index], path[1:], newValue)
+		return updated
+	}
+	return value
+}
+
+// IgnoreChanges adds attributes to the ignore_changes of the lifecycle block of item
+func IgnoreChanges(item map[string]interface{}, attributes ...string) {
+	lifecycle, _ := item["lifecycle"].(map[string]interface{})
+	if lifecycle == nil {
+		lifecycle = map[string]interface{}{}
+		item["lifecycle"] = lifecycle
+	}
+	ignored := []string{}
+	if current, ok := lifecycle["ignore_changes"].([]interface{}); ok {
+		for _, attribute := range current {
+			if name, ok := attribute.(string); ok {
+				ignored = append(ignored, name)
+			}
+		}
+	}
+	ignored = mergeStrings(ignored, attributes)
+	ignoreChanges := make([]interface{}, 0, len(ignored))
+	for _, attribute := range ignored {
+		ignoreChanges = append(ignoreChanges, attribute)
+	}
+	lifecycle["ignore_changes"] = ignoreChanges
+}
+
+// mergeStrings returns the sorted union of a and b, nil when both are empty
+func mergeStrings(a, b []string) []string {
+	seen := map[string]bool{}
+	var merged []string
+	for _, values := range [][]string{a, b} {
+		for _, value := range values {
+			if !seen[value] {
+				seen[value] = true
+				merged = append(merged, value)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
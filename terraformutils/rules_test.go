@@ -0,0 +1,123 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+const testRules = `
+"*":
+  ignore: ["^arn$"]
+fake_bucket:
+  ignore: ["^region$"]
+  allow_empty: ["^description$"]
+  set:
+    tags.owner: platform
+    force_destroy: true
+  replace:
+    - attribute: "^(name|(tags|labels)\\.env)$"
+      pattern: "^prod-(.*)"
+      replacement: "staging-$1"
+  lifecycle:
+    ignore_changes: [policy]
+    prevent_destroy: true
+`
+
+func TestApplyRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(testRules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := ReadRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := map[string]interface{}{"env": "prod-eu"}
+	resource := NewResource("b-1", "logs", "fake_bucket", "fake", map[string]string{
+		"id":          "b-1",
+		"arn":         "arn:fake:b-1",
+		"name":        "prod-logs",
+		"region":      "eu",
+		"description": "",
+		"policy":      "{}",
+		"tags.%":      "1",
+		"tags.env":    "prod-eu",
+	}, nil, map[string]interface{}{"labels": shared})
+	resource.ApplyRules(rules)
+
+	ignoreKeys := []*regexp.Regexp{}
+	for _, pattern := range resource.IgnoreKeys {
+		ignoreKeys = append(ignoreKeys, regexp.MustCompile(pattern))
+	}
+	allowEmptyValues := []*regexp.Regexp{}
+	for _, pattern := range resource.AllowEmptyValues {
+		allowEmptyValues = append(allowEmptyValues, regexp.MustCompile(pattern))
+	}
+	parser := NewFlatmapParser(resource.InstanceState.Attributes, ignoreKeys, allowEmptyValues)
+	err = resource.ParseTFstate(parser, cty.Object(map[string]cty.Type{
+		"id":          cty.String,
+		"arn":         cty.String,
+		"name":        cty.String,
+		"region":      cty.String,
+		"description": cty.String,
+		"policy":      cty.String,
+		"tags":        cty.Map(cty.String),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"id":            "b-1",
+		"name":          "staging-logs",
+		"description":   "",
+		"policy":        "{}",
+		"force_destroy": true,
+		"labels":        map[string]interface{}{"env": "staging-eu"},
+		"tags":          map[string]interface{}{"env": "staging-eu", "owner": "platform"},
+		"lifecycle": map[string]interface{}{
+			"ignore_changes":  []interface{}{"policy"},
+			"prevent_destroy": true,
+		},
+	}
+	if !reflect.DeepEqual(resource.Item, expected) {
+		t.Errorf("unexpected item\n%#v\nexpected\n%#v", resource.Item, expected)
+	}
+	if shared["env"] != "prod-eu" {
+		t.Error("rules changed a map shared by AdditionalFields")
+	}
+
+	other := NewSimpleResource("q-1", "queue", "fake_queue", "fake", nil)
+	other.ApplyRules(rules)
+	if !reflect.DeepEqual(other.IgnoreKeys, []string{"^arn$"}) || other.Rules.Set != nil {
+		t.Errorf("only the rules of every type should apply to fake_queue, got %v %+v", other.IgnoreKeys, other.Rules)
+	}
+}
+
+func TestReadRulesChecksPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("fake_bucket:\n  replace:\n    - attribute: name\n      pattern: \"(\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRules(path); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
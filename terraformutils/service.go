@@ -15,6 +15,7 @@
 package terraformutils
 
 import (
+	"context"
 	"log"
 	"strings"
 
@@ -32,6 +33,7 @@ type ServiceGenerator interface {
 	SetArgs(args map[string]interface{})
 	SetName(name string)
 	SetVerbose(bool)
+	SetContext(ctx context.Context)
 	SetProviderName(name string)
 	GetProviderName() string
 	GetName() string
@@ -47,6 +49,20 @@ type Service struct {
 	Args         map[string]interface{}
 	Filter       []ResourceFilter
 	Verbose      bool
+	ctx          context.Context
+}
+
+// SetContext sets the context passed to API calls, it is canceled on interrupt or timeout
+func (s *Service) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
+// Context returns the import context, context.Background() when none was set
+func (s *Service) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 func (s *Service) SetProviderName(providerName string) {
@@ -165,3 +181,14 @@ func (s *Service) PopulateIgnoreKeys(providerWrapper *providerwrapper.ProviderWr
 		}
 	}
 }
+
+// SetRefreshStrategy selects how resources of the given types are refreshed, call it at the end of InitResources
+func (s *Service) SetRefreshStrategy(strategy providerwrapper.RefreshStrategy, resourceTypes ...string) {
+	for i := range s.Resources {
+		for _, resourceType := range resourceTypes {
+			if s.Resources[i].InstanceInfo.Type == resourceType {
+				s.Resources[i].RefreshStrategy = strategy
+			}
+		}
+	}
+}
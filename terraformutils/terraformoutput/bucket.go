@@ -15,7 +15,7 @@ package terraformoutput
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"strings"
 
 	"cloud.google.com/go/storage"
@@ -50,8 +50,9 @@ func (b BucketState) BucketUpload(path string, file []byte) error {
 	ctx := context.Background()
 	client, err := storage.NewClient(ctx)
 	if err != nil {
-		log.Fatalf("Failed to create client: %v", err)
+		return fmt.Errorf("failed to create client: %v", err)
 	}
+	defer client.Close()
 	name := strings.ReplaceAll(b.Name, "gs://", "")
 	wc := client.Bucket(name).Object(b.BucketPrefix(path) + "/default.tfstate").NewWriter(ctx)
 	if _, err = wc.Write(file); err != nil {
@@ -14,6 +14,7 @@
 package terraformoutput
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -28,28 +29,48 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 	if err := os.MkdirAll(path, os.ModePerm); err != nil {
 		return err
 	}
+	return WriteHclFiles(FileWriter{}, resources, provider, path, serviceName, isCompact, output, sort)
+}
 
-	providerConfig := map[string]interface{}{
-		"version": providerwrapper.GetProviderVersion(provider.GetName()),
-	}
+// WriteHclFiles renders provider, outputs and resources files of path and stores them with writer
+func WriteHclFiles(writer Writer, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator, path string, serviceName string, isCompact bool, output string, sort bool) error {
+	return WriteHclFilesWithProviderData(writer, resources, provider, provider.GetProviderData(), map[string]struct{}{}, path, serviceName, isCompact, output, sort)
+}
 
-	if providerWithSource, ok := provider.(terraformutils.ProviderWithSource); ok {
-		providerConfig["source"] = providerWithSource.GetSource()
+// WriteHclFilesWithProviderData is WriteHclFiles with the provider file rendered from providerData,
+// providerMaps are the paths of its map attributes as for terraformutils.Print
+func WriteHclFilesWithProviderData(writer Writer, resources []terraformutils.Resource, provider terraformutils.ProviderGenerator,
+	providerData map[string]interface{}, providerMaps map[string]struct{}, path string, serviceName string, isCompact bool, output string, sort bool) error {
+	providerConfig := map[string]interface{}{}
+	lock, err := providerwrapper.GetProviderLock(provider.GetName())
+	if err == nil {
+		providerConfig["version"] = lock.Version
+		providerConfig["source"] = lock.Source
+		if err := writer.WriteFile(path+"/.terraform.lock.hcl", lockFile(lock)); err != nil {
+			return err
+		}
+	} else {
+		log.Println(err)
+		providerConfig["version"] = providerwrapper.GetProviderVersion(provider.GetName())
+		if providerWithSource, ok := provider.(terraformutils.ProviderWithSource); ok {
+			providerConfig["source"] = providerWithSource.GetSource()
+		}
 	}
 
 	// create provider file
-	providerData := provider.GetProviderData()
 	providerData["terraform"] = map[string]interface{}{
 		"required_providers": []map[string]interface{}{{
 			provider.GetName(): providerConfig,
 		}},
 	}
 
-	providerDataFile, err := terraformutils.Print(providerData, map[string]struct{}{}, output, sort)
+	providerDataFile, err := terraformutils.Print(providerData, providerMaps, output, sort)
 	if err != nil {
 		return err
 	}
-	PrintFile(path+"/provider."+GetFileExtension(output), providerDataFile)
+	if err := writer.WriteFile(path+"/provider."+GetFileExtension(output), providerDataFile); err != nil {
+		return err
+	}
 
 	// create outputs files
 	outputs := map[string]interface{}{}
@@ -92,7 +113,9 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 		if err != nil {
 			return err
 		}
-		PrintFile(path+"/outputs."+GetFileExtension(output), outputsFile)
+		if err := writer.WriteFile(path+"/outputs."+GetFileExtension(output), outputsFile); err != nil {
+			return err
+		}
 	}
 
 	// group by resource by type
@@ -101,14 +124,14 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 		typeOfServices[r.InstanceInfo.Type] = append(typeOfServices[r.InstanceInfo.Type], r)
 	}
 	if isCompact {
-		err := printFile(resources, "resources", path, output, sort)
+		err := printFile(writer, resources, "resources", path, output, sort)
 		if err != nil {
 			return err
 		}
 	} else {
 		for k, v := range typeOfServices {
 			fileName := strings.ReplaceAll(k, strings.Split(k, "_")[0]+"_", "")
-			err := printFile(v, fileName, path, output, sort)
+			err := printFile(writer, v, fileName, path, output, sort)
 			if err != nil {
 				return err
 			}
@@ -117,16 +140,13 @@ func OutputHclFiles(resources []terraformutils.Resource, provider terraformutils
 	return nil
 }
 
-func printFile(v []terraformutils.Resource, fileName, path, output string, sort bool) error {
+func printFile(writer Writer, v []terraformutils.Resource, fileName, path, output string, sort bool) error {
 	for _, res := range v {
 		if res.DataFiles == nil {
 			continue
 		}
 		for fileName, content := range res.DataFiles {
-			if err := os.MkdirAll(path+"/data/", os.ModePerm); err != nil {
-				return err
-			}
-			err := os.WriteFile(path+"/data/"+fileName, content, os.ModePerm)
+			err := writer.WriteFile(path+"/data/"+fileName, content)
 			if err != nil {
 				return err
 			}
@@ -137,12 +157,26 @@ func printFile(v []terraformutils.Resource, fileName, path, output string, sort
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(path+"/"+fileName+"."+GetFileExtension(output), tfFile, os.ModePerm)
-	if err != nil {
-		return err
-	}
+	return writer.WriteFile(path+"/"+fileName+"."+GetFileExtension(output), tfFile)
+}
 
-	return nil
+// lockFile renders .terraform.lock.hcl so terraform init selects exactly the provider used for import
+func lockFile(lock *providerwrapper.ProviderLock) []byte {
+	var b strings.Builder
+	b.WriteString("# This file is maintained automatically by \"terraform init\".\n")
+	b.WriteString("# Manual edits may be lost in future updates.\n\n")
+	fmt.Fprintf(&b, "provider %q {\n", lock.Address)
+	fmt.Fprintf(&b, "  version     = %q\n", lock.Version)
+	fmt.Fprintf(&b, "  constraints = %q\n", lock.Version)
+	if len(lock.Hashes) > 0 {
+		b.WriteString("  hashes = [\n")
+		for _, hash := range lock.Hashes {
+			fmt.Fprintf(&b, "    %q,\n", hash)
+		}
+		b.WriteString("  ]\n")
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
 }
 
 func PrintFile(path string, data []byte) {
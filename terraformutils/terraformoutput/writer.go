@@ -0,0 +1,140 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Writer stores generated files, path is built from the path pattern and a file name
+type Writer interface {
+	WriteFile(path string, data []byte) error
+}
+
+// FileWriter writes files to disk, creating missing directories
+type FileWriter struct{}
+
+func (FileWriter) WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, os.ModePerm)
+}
+
+// MemoryWriter keeps files in memory by cleaned path, it is safe for concurrent use
+type MemoryWriter struct {
+	mutex sync.Mutex
+	Files map[string][]byte
+}
+
+func NewMemoryWriter() *MemoryWriter {
+	return &MemoryWriter{Files: map[string][]byte{}}
+}
+
+func (w *MemoryWriter) WriteFile(path string, data []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.Files[pathpkg.Clean(path)] = append([]byte(nil), data...)
+	return nil
+}
+
+// MultiWriter writes files to every writer, stopping at the first error
+func MultiWriter(writers ...Writer) Writer {
+	return multiWriter(writers)
+}
+
+type multiWriter []Writer
+
+func (w multiWriter) WriteFile(path string, data []byte) error {
+	for _, writer := range w {
+		if err := writer.WriteFile(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StagingWriter writes files to a temporary directory and moves them to their path on Commit,
+// so an interrupted import leaves no partial output behind
+type StagingWriter struct {
+	mutex  sync.Mutex
+	dir    string
+	staged map[string]string
+	paths  []string
+}
+
+// NewStagingWriter creates the temporary directory in dir, which should be on the same
+// filesystem as the output so Commit can rename files
+func NewStagingWriter(dir string) (*StagingWriter, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	tempDir, err := os.MkdirTemp(dir, ".terraformer-")
+	if err != nil {
+		return nil, err
+	}
+	return &StagingWriter{dir: tempDir, staged: map[string]string{}}, nil
+}
+
+func (w *StagingWriter) WriteFile(path string, data []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	path = filepath.Clean(path)
+	staged, exist := w.staged[path]
+	if !exist {
+		staged = filepath.Join(w.dir, strconv.Itoa(len(w.paths)))
+		w.staged[path] = staged
+		w.paths = append(w.paths, path)
+	}
+	return os.WriteFile(staged, data, os.ModePerm)
+}
+
+// Commit moves the staged files to their path and removes the temporary directory
+func (w *StagingWriter) Commit() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, path := range w.paths {
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		staged := w.staged[path]
+		if err := os.Rename(staged, path); err != nil {
+			// the output is on another filesystem
+			data, readErr := os.ReadFile(staged)
+			if readErr != nil {
+				return err
+			}
+			if err := os.WriteFile(path, data, os.ModePerm); err != nil {
+				return err
+			}
+		}
+	}
+	return os.RemoveAll(w.dir)
+}
+
+// Paths lists the paths of the written files in write order
+func (w *StagingWriter) Paths() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]string(nil), w.paths...)
+}
+
+// Discard removes the staged files
+func (w *StagingWriter) Discard() error {
+	return os.RemoveAll(w.dir)
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package terraformoutput
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStagingWriterCommit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "generated", "aws", "vpc", "vpc.tf")
+	writer, err := NewStagingWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteFile(path, []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteFile(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("file written before commit: %v", err)
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("expected new, got %s", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the output directory, got %v", entries)
+	}
+}
+
+func TestStagingWriterDiscard(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewStagingWriter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteFile(filepath.Join(dir, "generated", "vpc.tf"), []byte("vpc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Discard(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected empty directory, got %v", entries)
+	}
+}
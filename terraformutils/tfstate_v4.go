@@ -0,0 +1,145 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/version"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	StateFormatV3 = "v3"
+	StateFormatV4 = "v4"
+)
+
+// stateV4 and the types below follow the state format written by terraform 0.12 and later
+type stateV4 struct {
+	Version          int                      `json:"version"`
+	TerraformVersion string                   `json:"terraform_version"`
+	Serial           int                      `json:"serial"`
+	Lineage          string                   `json:"lineage"`
+	Outputs          map[string]outputStateV4 `json:"outputs"`
+	Resources        []resourceStateV4        `json:"resources"`
+}
+
+type outputStateV4 struct {
+	Value     json.RawMessage `json:"value"`
+	Type      json.RawMessage `json:"type"`
+	Sensitive bool            `json:"sensitive,omitempty"`
+}
+
+type resourceStateV4 struct {
+	Mode      string                  `json:"mode"`
+	Type      string                  `json:"type"`
+	Name      string                  `json:"name"`
+	Provider  string                  `json:"provider"`
+	Instances []instanceObjectStateV4 `json:"instances"`
+}
+
+type instanceObjectStateV4 struct {
+	SchemaVersion  int               `json:"schema_version"`
+	Attributes     json.RawMessage   `json:"attributes,omitempty"`
+	AttributesFlat map[string]string `json:"attributes_flat,omitempty"`
+}
+
+// PrintTfStateV4 renders resources as a version 4 state, providerAddress is the fully qualified
+// provider source, e.g. registry.terraform.io/hashicorp/aws. Attributes come from the flatmap
+// InstanceState of resources, as changed by their generators, typed with impliedTypes, the
+// object types of the resource types in the provider schema. Terraform upgrades the flatmap
+// attributes of resources of other types, e.g. loaded from a plan, on read.
+func PrintTfStateV4(resources []Resource, providerAddress string, impliedTypes map[string]cty.Type) ([]byte, error) {
+	lineage, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	state := stateV4{
+		Version:          4,
+		TerraformVersion: version.Version,
+		Serial:           1,
+		Lineage:          lineage,
+		Outputs:          map[string]outputStateV4{},
+		Resources:        []resourceStateV4{},
+	}
+	for _, r := range resources {
+		for name, output := range r.Outputs {
+			value, err := json.Marshal(output.Value)
+			if err != nil {
+				return nil, err
+			}
+			state.Outputs[name] = outputStateV4{
+				Value:     value,
+				Type:      json.RawMessage(strconv.Quote(output.Type)),
+				Sensitive: output.Sensitive,
+			}
+		}
+
+		instance := instanceObjectStateV4{SchemaVersion: schemaVersion(r)}
+		if impliedType, exist := impliedTypes[r.InstanceInfo.Type]; exist {
+			value, err := r.InstanceState.AttrsAsObjectValue(impliedType)
+			if err != nil {
+				return nil, err
+			}
+			instance.Attributes, err = ctyjson.Marshal(value, impliedType)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			instance.AttributesFlat = r.InstanceState.Attributes
+		}
+		state.Resources = append(state.Resources, resourceStateV4{
+			Mode:      "managed",
+			Type:      r.InstanceInfo.Type,
+			Name:      r.ResourceName,
+			Provider:  `provider["` + providerAddress + `"]`,
+			Instances: []instanceObjectStateV4{instance},
+		})
+	}
+	sort.Slice(state.Resources, func(i, j int) bool {
+		if state.Resources[i].Type != state.Resources[j].Type {
+			return state.Resources[i].Type < state.Resources[j].Type
+		}
+		return state.Resources[i].Name < state.Resources[j].Name
+	})
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaVersion returns the schema version recorded by refresh, plan files decode it as a float
+func schemaVersion(r Resource) int {
+	if r.InstanceState == nil {
+		return 0
+	}
+	switch v := r.InstanceState.Meta["schema_version"].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		version, _ := strconv.Atoi(v)
+		return version
+	}
+	return 0
+}
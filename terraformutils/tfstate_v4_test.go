@@ -0,0 +1,108 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestPrintTfStateV4(t *testing.T) {
+	value := cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal("vpc-1"),
+		"cidr": cty.StringVal("10.0.0.0/16"),
+		"mtu":  cty.NumberIntVal(1500),
+	})
+	vpc := NewSimpleResource("vpc-1", "main", "aws_vpc", "aws", []string{})
+	vpc.Value = value
+	vpc.InstanceState = terraform.NewInstanceStateShimmedFromValue(value, 1)
+	vpc.Outputs = map[string]*terraform.OutputState{
+		"aws_vpc_tfer--main_id": {Type: "string", Value: "vpc-1"},
+	}
+	// resources loaded from a plan only have flatmap attributes
+	subnet := NewResource("subnet-1", "a", "aws_subnet", "aws",
+		map[string]string{"id": "subnet-1", "vpc_id": "vpc-1"}, []string{}, map[string]interface{}{})
+	subnet.InstanceState.Meta = map[string]interface{}{"schema_version": float64(2)}
+
+	data, err := PrintTfStateV4([]Resource{vpc, subnet}, "registry.terraform.io/hashicorp/aws", map[string]cty.Type{"aws_vpc": value.Type()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := stateV4{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Version != 4 || state.Lineage == "" || state.Serial != 1 {
+		t.Errorf("unexpected state header %d %q %d", state.Version, state.Lineage, state.Serial)
+	}
+	if output := state.Outputs["aws_vpc_tfer--main_id"]; string(output.Value) != `"vpc-1"` || string(output.Type) != `"string"` {
+		t.Errorf("unexpected output %s %s", output.Value, output.Type)
+	}
+	if len(state.Resources) != 2 {
+		t.Fatalf("expected 2 resources:\n%s", data)
+	}
+
+	subnetState, vpcState := state.Resources[0], state.Resources[1]
+	if vpcState.Type != "aws_vpc" || vpcState.Name != "tfer--main" || vpcState.Mode != "managed" ||
+		vpcState.Provider != `provider["registry.terraform.io/hashicorp/aws"]` {
+		t.Errorf("unexpected vpc address %+v", vpcState)
+	}
+	attributes := bytes.Buffer{}
+	if err := json.Compact(&attributes, vpcState.Instances[0].Attributes); err != nil {
+		t.Fatal(err)
+	}
+	if vpcState.Instances[0].SchemaVersion != 1 || attributes.String() != `{"cidr":"10.0.0.0/16","id":"vpc-1","mtu":1500}` {
+		t.Errorf("unexpected vpc instance %d %s", vpcState.Instances[0].SchemaVersion, attributes.String())
+	}
+	if instance := subnetState.Instances[0]; instance.SchemaVersion != 2 || instance.AttributesFlat["vpc_id"] != "vpc-1" {
+		t.Errorf("unexpected subnet instance %d %v", instance.SchemaVersion, instance.AttributesFlat)
+	}
+}
+
+func TestPrintTfStateV4KeepsHookChanges(t *testing.T) {
+	value := cty.ObjectVal(map[string]cty.Value{
+		"id":        cty.StringVal("secret/app"),
+		"path":      cty.StringVal("secret/app"),
+		"data_json": cty.StringVal(`{"password":"hunter2"}`),
+	})
+	secret := NewSimpleResource("secret/app", "app", "vault_generic_secret", "vault", []string{})
+	secret.Value = value
+	secret.InstanceState = terraform.NewInstanceStateShimmedFromValue(value, 0)
+	// a PostConvertHook redacts the secret from the state
+	delete(secret.InstanceState.Attributes, "data_json")
+
+	data, err := PrintTfStateV4([]Resource{secret}, "registry.terraform.io/hashicorp/vault", map[string]cty.Type{"vault_generic_secret": value.Type()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Errorf("the state holds the attribute removed by the hook:\n%s", data)
+	}
+	state := stateV4{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	attributes := map[string]interface{}{}
+	if err := json.Unmarshal(state.Resources[0].Instances[0].Attributes, &attributes); err != nil {
+		t.Fatal(err)
+	}
+	if attributes["data_json"] != nil || attributes["path"] != "secret/app" {
+		t.Errorf("unexpected attributes %v", attributes)
+	}
+}
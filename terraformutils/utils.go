@@ -16,12 +16,14 @@ package terraformutils
 
 import (
 	"bytes"
+	"context"
 	"log"
 	"sync"
 
 	"github.com/GoogleCloudPlatform/terraformer/terraformutils/providerwrapper"
 
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type BaseResource struct {
@@ -65,7 +67,9 @@ func PrintTfState(resources []Resource) ([]byte, error) {
 	return buf.Bytes(), err
 }
 
-func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderWrapper, slowProcessingResources [][]*Resource) ([]*Resource, error) {
+// RefreshResources refreshes resources concurrently, keepValues keeps the refreshed values of
+// resources along with their flatmap state, for ConvertValues
+func RefreshResources(ctx context.Context, resources []*Resource, provider *providerwrapper.ProviderWrapper, slowProcessingResources [][]*Resource, keepValues bool) ([]*Resource, error) {
 	refreshedResources := []*Resource{}
 	input := make(chan *Resource, len(resources))
 	var wg sync.WaitGroup
@@ -77,7 +81,7 @@ func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderW
 	close(input)
 
 	for i := 0; i < poolSize; i++ {
-		go RefreshResourceWorker(input, &wg, provider)
+		go RefreshResourceWorker(ctx, input, &wg, provider, keepValues)
 	}
 
 	spInputs := []chan *Resource{}
@@ -91,10 +95,13 @@ func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderW
 
 	for i := 0; i < len(spInputs); i++ {
 		wg.Add(len(slowProcessingResources[i]))
-		go RefreshResourceWorker(spInputs[i], &wg, provider)
+		go RefreshResourceWorker(ctx, spInputs[i], &wg, provider, keepValues)
 	}
 
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	for _, r := range resources {
 		if r.InstanceState != nil && r.InstanceState.ID != "" {
 			refreshedResources = append(refreshedResources, r)
@@ -116,7 +123,7 @@ func RefreshResources(resources []*Resource, provider *providerwrapper.ProviderW
 	return refreshedResources, nil
 }
 
-func RefreshResourcesByProvider(providersMapping *ProvidersMapping, providerWrapper *providerwrapper.ProviderWrapper) error {
+func RefreshResourcesByProvider(ctx context.Context, providersMapping *ProvidersMapping, providerWrapper *providerwrapper.ProviderWrapper, keepValues bool) error {
 	allResources := providersMapping.ShuffleResources()
 	slowProcessingResources := make(map[ProviderGenerator][]*Resource)
 	regularResources := []*Resource{}
@@ -138,7 +145,7 @@ func RefreshResourcesByProvider(providersMapping *ProvidersMapping, providerWrap
 		spResourcesList = append(spResourcesList, slowProcessingResources[p])
 	}
 
-	refreshedResources, err := RefreshResources(regularResources, providerWrapper, spResourcesList)
+	refreshedResources, err := RefreshResources(ctx, regularResources, providerWrapper, spResourcesList, keepValues)
 	if err != nil {
 		return err
 	}
@@ -147,10 +154,18 @@ func RefreshResourcesByProvider(providersMapping *ProvidersMapping, providerWrap
 	return nil
 }
 
-func RefreshResourceWorker(input chan *Resource, wg *sync.WaitGroup, provider *providerwrapper.ProviderWrapper) {
+func RefreshResourceWorker(ctx context.Context, input chan *Resource, wg *sync.WaitGroup, provider *providerwrapper.ProviderWrapper, keepValues bool) {
 	for r := range input {
+		if ctx.Err() != nil { // drain the queue without calling the plugin
+			wg.Done()
+			continue
+		}
 		log.Println("Refreshing state...", r.InstanceInfo.Id)
 		r.Refresh(provider)
+		if !keepValues {
+			// the flatmap state is enough, don't hold both representations
+			r.Value = cty.NilVal
+		}
 		wg.Done()
 	}
 }
@@ -0,0 +1,209 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ValueConverter converts a refreshed cty.Value to a resource item guided by the resource schema,
+// it replaces FlatmapParser for resources that keep their value.
+//
+// Unlike the flatmap path, numbers and bools keep their type, maps of objects are supported and
+// read-only attributes are dropped using the schema. Null values are dropped, empty values are
+// dropped unless the attribute is required or matches allowEmptyValues.
+type ValueConverter struct {
+	// ignoreKeys and allowEmptyValues match flatmap keys such as "ingress.0.self", so the patterns
+	// of the flatmap path keep working
+	ignoreKeys       []*regexp.Regexp
+	allowEmptyValues []*regexp.Regexp
+	// ignorePaths are attribute paths without indexes such as "ingress.self"
+	ignorePaths map[string]bool
+}
+
+func NewValueConverter(ignoreKeys []*regexp.Regexp, allowEmptyValues []*regexp.Regexp, ignorePaths []string) *ValueConverter {
+	c := &ValueConverter{
+		ignoreKeys:       ignoreKeys,
+		allowEmptyValues: allowEmptyValues,
+		ignorePaths:      map[string]bool{},
+	}
+	for _, path := range ignorePaths {
+		c.ignorePaths[path] = true
+	}
+	return c
+}
+
+// Convert returns the item of value, an object conforming to block
+func (c *ValueConverter) Convert(value cty.Value, block *configschema.Block) map[string]interface{} {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	item := c.convertBlock(value, block, "", "")
+	// the ID is set by import, never in configuration
+	delete(item, "id")
+	return item
+}
+
+// convertBlock converts the attributes and nested blocks of value, key is the flatmap prefix
+// and path the attribute path prefix of value
+func (c *ValueConverter) convertBlock(value cty.Value, block *configschema.Block, key, path string) map[string]interface{} {
+	item := map[string]interface{}{}
+	for name, attribute := range block.Attributes {
+		if !attribute.Optional && !attribute.Required {
+			continue
+		}
+		if !value.Type().HasAttribute(name) || c.isIgnored(key+name, path+name) {
+			continue
+		}
+		converted := c.convertValue(value.GetAttr(name), key+name, path+name)
+		if converted != nil && (attribute.Required || !isEmpty(converted) || c.isEmptyAllowed(key+name)) {
+			item[name] = converted
+		}
+	}
+	for name, nested := range block.BlockTypes {
+		if !value.Type().HasAttribute(name) || c.isIgnored(key+name, path+name) {
+			continue
+		}
+		converted := c.convertNestedBlock(value.GetAttr(name), nested, key+name, path+name)
+		if converted != nil && !isEmpty(converted) {
+			item[name] = converted
+		}
+	}
+	return item
+}
+
+func (c *ValueConverter) convertNestedBlock(value cty.Value, nested *configschema.NestedBlock, key, path string) interface{} {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	switch nested.Nesting {
+	case configschema.NestingSingle, configschema.NestingGroup:
+		return c.convertBlock(value, &nested.Block, key+".", path+".")
+	case configschema.NestingMap:
+		blocks := map[string]interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if block := c.convertBlock(v, &nested.Block, key+"."+k.AsString()+".", path+"."); len(block) > 0 {
+				blocks[k.AsString()] = block
+			}
+		}
+		return blocks
+	default:
+		var blocks []interface{}
+		i := 0
+		for it := value.ElementIterator(); it.Next(); i++ {
+			_, v := it.Element()
+			if block := c.convertBlock(v, &nested.Block, key+"."+strconv.Itoa(i)+".", path+"."); len(block) > 0 {
+				blocks = append(blocks, block)
+			}
+		}
+		return blocks
+	}
+}
+
+// convertValue converts an attribute value by its type
+func (c *ValueConverter) convertValue(value cty.Value, key, path string) interface{} {
+	if value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	ty := value.Type()
+	switch {
+	case ty == cty.String:
+		return value.AsString()
+	case ty == cty.Bool:
+		return value.True()
+	case ty == cty.Number:
+		number := value.AsBigFloat()
+		if number.IsInt() {
+			if i, accuracy := number.Int64(); accuracy == 0 {
+				return i
+			}
+		}
+		f, _ := number.Float64()
+		return f
+	case ty.IsObjectType() || ty.IsMapType():
+		values := map[string]interface{}{}
+		for it := value.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			elementKey := key + "." + k.AsString()
+			elementPath := path
+			if ty.IsObjectType() {
+				elementPath = path + "." + k.AsString()
+			}
+			if c.isIgnored(elementKey, elementPath) {
+				continue
+			}
+			converted := c.convertValue(v, elementKey, elementPath)
+			if converted != nil && (!isEmpty(converted) || c.isEmptyAllowed(key+".")) {
+				values[k.AsString()] = converted
+			}
+		}
+		return values
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		var values []interface{}
+		i := 0
+		for it := value.ElementIterator(); it.Next(); i++ {
+			_, v := it.Element()
+			elementKey := key + "." + strconv.Itoa(i)
+			if c.isIgnored(elementKey, path) {
+				continue
+			}
+			converted := c.convertValue(v, elementKey, path)
+			if converted != nil && (!isEmpty(converted) || c.isEmptyAllowed(key+".")) {
+				values = append(values, converted)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func (c *ValueConverter) isIgnored(key, path string) bool {
+	if c.ignorePaths[path] {
+		return true
+	}
+	for _, pattern := range c.ignoreKeys {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ValueConverter) isEmptyAllowed(key string) bool {
+	for _, pattern := range c.allowEmptyValues {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty reports whether a converted value is an empty string or collection, false and 0 aren't empty
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraformutils
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var valueConverterSchema = &configschema.Block{
+	Attributes: map[string]*configschema.Attribute{
+		"id":          {Type: cty.String, Optional: true, Computed: true},
+		"arn":         {Type: cty.String, Computed: true},
+		"name":        {Type: cty.String, Required: true},
+		"description": {Type: cty.String, Optional: true},
+		"port":        {Type: cty.Number, Optional: true},
+		"ratio":       {Type: cty.Number, Optional: true},
+		"enabled":     {Type: cty.Bool, Optional: true},
+		"tags":        {Type: cty.Map(cty.String), Optional: true},
+		"routes": {Type: cty.Map(cty.Object(map[string]cty.Type{
+			"target": cty.String,
+			"weight": cty.Number,
+		})), Optional: true},
+	},
+	BlockTypes: map[string]*configschema.NestedBlock{
+		"ingress": {Nesting: configschema.NestingList, Block: configschema.Block{
+			Attributes: map[string]*configschema.Attribute{
+				"from_port": {Type: cty.Number, Required: true},
+				"self":      {Type: cty.Bool, Optional: true},
+				"rule_id":   {Type: cty.String, Computed: true},
+			},
+		}},
+	},
+}
+
+func valueConverterValue() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"id":          cty.StringVal("sg-1"),
+		"arn":         cty.StringVal("arn:sg-1"),
+		"name":        cty.StringVal(""),
+		"description": cty.StringVal(""),
+		"port":        cty.NumberIntVal(0),
+		"ratio":       cty.NumberFloatVal(0.5),
+		"enabled":     cty.False,
+		"tags":        cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("web"), "Empty": cty.StringVal("")}),
+		"routes": cty.MapVal(map[string]cty.Value{"default": cty.ObjectVal(map[string]cty.Value{
+			"target": cty.StringVal("igw"),
+			"weight": cty.NumberIntVal(10),
+		})}),
+		"ingress": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"from_port": cty.NumberIntVal(443),
+			"self":      cty.True,
+			"rule_id":   cty.StringVal("r-1"),
+		})}),
+	})
+}
+
+func TestValueConverter(t *testing.T) {
+	item := NewValueConverter(nil, nil, nil).Convert(valueConverterValue(), valueConverterSchema)
+
+	expected := map[string]interface{}{
+		"name":    "",
+		"port":    int64(0),
+		"ratio":   0.5,
+		"enabled": false,
+		"tags":    map[string]interface{}{"Name": "web"},
+		"routes": map[string]interface{}{"default": map[string]interface{}{
+			"target": "igw",
+			"weight": int64(10),
+		}},
+		"ingress": []interface{}{map[string]interface{}{
+			"from_port": int64(443),
+			"self":      true,
+		}},
+	}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("expected %#v, got %#v", expected, item)
+	}
+}
+
+func TestValueConverterIgnoreRules(t *testing.T) {
+	item := NewValueConverter(
+		[]*regexp.Regexp{regexp.MustCompile(`^routes\.`)},
+		[]*regexp.Regexp{regexp.MustCompile(`tags.`)},
+		[]string{"ingress.self", "ratio"},
+	).Convert(valueConverterValue(), valueConverterSchema)
+
+	if _, exist := item["ratio"]; exist {
+		t.Errorf("ignored path ratio was converted")
+	}
+	if routes, exist := item["routes"]; exist {
+		t.Errorf("ignored key routes.default was converted: %v", routes)
+	}
+	if ingress := item["ingress"].([]interface{})[0].(map[string]interface{}); ingress["self"] != nil {
+		t.Errorf("ignored path ingress.self was converted: %v", ingress)
+	}
+	if tags := item["tags"].(map[string]interface{}); tags["Empty"] != "" {
+		t.Errorf("allowed empty tag was dropped: %v", tags)
+	}
+}
@@ -17,11 +17,9 @@ package main
 import (
 	"log"
 	"os"
-	"os/exec"
 	"time"
 
 	"github.com/GoogleCloudPlatform/terraformer/cmd"
-	aws_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/aws"
 )
 
 func main() {
@@ -36,37 +34,14 @@ func main() {
 		"--verbose",
 		"--compact",
 		"--path-pattern=" + pathPattern,
+		// terraform init + plan must show no diff
+		"--verify",
+		"--verify-strict",
 	})
 	start := time.Now()
 	if err := tCommand.Execute(); err != nil {
 		log.Println(err)
 		os.Exit(1)
 	}
-	log.Printf("Importing took %s", time.Since(start))
-	start = time.Now()
-	runTerraform(pathPattern)
-	log.Printf("Terraform init + plan took %s", time.Since(start))
-}
-
-func runTerraform(pathPattern string) {
-	rootPath, _ := os.Getwd()
-	provider := &aws_terraforming.AWSProvider{}
-
-	currentPath := cmd.Path(pathPattern, provider.GetName(), "", cmd.DefaultPathOutput)
-	if err := os.Chdir(currentPath); err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
-	tfCmd := exec.Command("sh", "-c", "terraform init && terraform plan")
-	tfCmd.Stdout = os.Stdout
-	tfCmd.Stderr = os.Stderr
-	err := tfCmd.Run()
-	if err != nil {
-		log.Println(err)
-		os.Exit(1)
-	}
-	err = os.Chdir(rootPath)
-	if err != nil {
-		log.Println(err)
-	}
+	log.Printf("Importing and verifying took %s", time.Since(start))
 }
@@ -0,0 +1,120 @@
+// Copyright 2019 The Terraformer Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"log"
+	"os"
+	"os/exec"
+
+	"github.com/GoogleCloudPlatform/terraformer/cmd"
+	vault_terraforming "github.com/GoogleCloudPlatform/terraformer/providers/vault"
+	vault "github.com/hashicorp/vault/api"
+)
+
+const command = "terraform init && terraform plan -detailed-exitcode"
+
+var services = []string{
+	"identity_entity",
+	"identity_entity_alias",
+	"identity_group",
+	"identity_group_alias",
+	"identity_oidc_key",
+	"identity_oidc_role",
+	"pki_secret_backend",
+	"pki_secret_backend_config_urls",
+	"pki_secret_backend_role",
+	"generic_secret",
+	"kv_secret_backend_v2",
+}
+
+func main() {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		address = "http://127.0.0.1:8200"
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		token = "root"
+	}
+	if err := seed(address, token); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	provider := &vault_terraforming.Provider{}
+	err := cmd.Import(provider, cmd.ImportOptions{
+		Resources:   services,
+		PathPattern: cmd.DefaultPathPattern,
+		PathOutput:  cmd.DefaultPathOutput,
+		State:       "local",
+		Connect:     true,
+		Output:      "hcl",
+	}, []string{address, token, "0", "false", ""})
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	rootPath, _ := os.Getwd()
+	for _, serviceName := range services {
+		currentPath := cmd.Path(cmd.DefaultPathPattern, provider.GetName(), serviceName, cmd.DefaultPathOutput)
+		if err := os.Chdir(currentPath); err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err = cmd.Run()
+		if err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		err := os.Chdir(rootPath)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// seed creates a few resources of every imported service on a dev-mode server (vault server -dev)
+func seed(address, token string) error {
+	client, err := vault.NewClient(&vault.Config{Address: address})
+	if err != nil {
+		return err
+	}
+	client.SetToken(token)
+	writes := []struct {
+		path string
+		data map[string]interface{}
+	}{
+		{"sys/mounts/pki", map[string]interface{}{"type": "pki"}},
+		{"pki/root/generate/internal", map[string]interface{}{"common_name": "terraformer.test"}},
+		{"pki/config/urls", map[string]interface{}{"issuing_certificates": "http://127.0.0.1:8200/v1/pki/ca"}},
+		{"pki/roles/terraformer", map[string]interface{}{"allowed_domains": "terraformer.test"}},
+		{"sys/mounts/kv1", map[string]interface{}{"type": "kv", "options": map[string]interface{}{"version": "1"}}},
+		{"kv1/team/app/db", map[string]interface{}{"password": "terraformer"}},
+		{"secret/data/team/app/db", map[string]interface{}{"data": map[string]interface{}{"password": "terraformer"}}},
+		{"identity/entity", map[string]interface{}{"name": "terraformer"}},
+		{"identity/group", map[string]interface{}{"name": "terraformer"}},
+		{"identity/oidc/key/terraformer", map[string]interface{}{"algorithm": "RS256"}},
+		{"identity/oidc/role/terraformer", map[string]interface{}{"key": "terraformer"}},
+	}
+	for _, w := range writes {
+		if _, err := client.Logical().Write(w.path, w.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}